@@ -69,7 +69,8 @@ func New() *Browser {
 	}
 }
 
-// Incognito creates a new incognito browser
+// Incognito creates a new incognito browser, it has its own cookies, storage, and cache.
+// Close it to dispose all its pages, the original browser won't be affected.
 func (b *Browser) Incognito() (*Browser, error) {
 	res, err := proto.TargetCreateBrowserContext{}.Call(b)
 	if err != nil {
@@ -148,8 +149,11 @@ func (b *Browser) Connect() error {
 	return b.setHeadless()
 }
 
-// Close the browser
+// Close the browser. If the browser is created by Incognito, only its browser context will be disposed.
 func (b *Browser) Close() error {
+	if b.BrowserContextID != "" {
+		return proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(b)
+	}
 	return proto.BrowserClose{}.Call(b)
 }
 
@@ -177,7 +181,7 @@ func (b *Browser) Page(url string) (p *Page, err error) {
 	return
 }
 
-// Pages retrieves all visible pages
+// Pages retrieves all visible pages, each of them is attached and ready to use.
 func (b *Browser) Pages() (Pages, error) {
 	list, err := proto.TargetGetTargets{}.Call(b)
 	if err != nil {
@@ -215,6 +219,12 @@ func (b *Browser) WaitEvent(e proto.Payload) (wait func()) {
 	return b.waitEvent(b.ctx, "", e)
 }
 
+// EventChannel returns a buffered channel that receives the events of the same type as e,
+// call stop to unsubscribe and close the channel. The size is the buffer size of the channel.
+func (b *Browser) EventChannel(e proto.Payload, size int) (events <-chan proto.Payload, stop func()) {
+	return b.eventChannel(b.ctx, "", e, size)
+}
+
 // If the any callback returns true the event loop will stop.
 // It will enable the related domains if not enabled, and recover them after wait ends.
 func (b *Browser) eachEvent(
@@ -222,6 +232,16 @@ func (b *Browser) eachEvent(
 	sessionID proto.TargetSessionID,
 	callbacks ...interface{},
 ) (wait func()) {
+	wait, _ = b.eachEventE(ctx, sessionID, callbacks...)
+	return
+}
+
+// same as eachEvent, but returns the first error of enabling the domains, the wait is still usable
+func (b *Browser) eachEventE(
+	ctx context.Context,
+	sessionID proto.TargetSessionID,
+	callbacks ...interface{},
+) (wait func(), err error) {
 	cbValues := make([]reflect.Value, len(callbacks))
 	eventTypes := make([]reflect.Type, len(callbacks))
 	recovers := make([]func(), len(callbacks))
@@ -241,7 +261,11 @@ func (b *Browser) eachEvent(
 		} else {
 			enable = reflect.New(proto.GetType(domain + ".enable")).Interface().(proto.Payload)
 		}
-		recovers[i] = b.Context(ctx).EnableDomain(sessionID, enable)
+		recover, e := b.Context(ctx).enableDomain(sessionID, enable)
+		if err == nil {
+			err = e
+		}
+		recovers[i] = recover
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -277,7 +301,7 @@ func (b *Browser) eachEvent(
 			}
 			return false
 		})
-	}
+	}, err
 }
 
 // waits for the next event for one time. It will also load the data into the event object.
@@ -300,10 +324,49 @@ func (b *Browser) waitEvent(ctx context.Context, sessionID proto.TargetSessionID
 	return b.eachEvent(ctx, sessionID, fnVal.Interface())
 }
 
+// sends the events of the same type as e to a buffered channel until the stop is called.
+func (b *Browser) eventChannel(
+	ctx context.Context,
+	sessionID proto.TargetSessionID,
+	e proto.Payload,
+	size int,
+) (<-chan proto.Payload, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	events := make(chan proto.Payload, size)
+	valFalse := reflect.ValueOf(false)
+
+	// dynamically creates a function on runtime:
+	//
+	// func(ee proto.Payload) bool {
+	//   events <- ee
+	//   return false
+	// }
+	fnType := reflect.FuncOf([]reflect.Type{reflect.TypeOf(e)}, []reflect.Type{valFalse.Type()}, false)
+	fnVal := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		select {
+		case <-ctx.Done():
+		case events <- args[0].Interface().(proto.Payload):
+		}
+		return []reflect.Value{valFalse}
+	})
+
+	wait := b.eachEvent(ctx, sessionID, fnVal.Interface())
+
+	go func() {
+		wait()
+		close(events)
+	}()
+
+	return events, cancel
+}
+
 // Call raw cdp interface directly
 func (b *Browser) Call(ctx context.Context, sessionID, methodName string, params json.RawMessage) (res []byte, err error) {
 	res, err = b.client.Call(ctx, sessionID, methodName, params)
 	if err != nil {
+		if b.crashed(proto.TargetSessionID(sessionID)) {
+			return nil, newErr(ErrPageCrashed, err, err.Error())
+		}
 		return nil, err
 	}
 
@@ -330,6 +393,8 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 	page = (&Page{
 		sleeper:       b.sleeper,
 		jsContextLock: &sync.Mutex{},
+		jsContext:     &jsContext{},
+		har:           &harRecorder{},
 		browser:       b,
 		TargetID:      targetID,
 		executionIDs:  map[proto.PageFrameID]proto.RuntimeExecutionContextID{},
@@ -361,6 +426,7 @@ func (b *Browser) initEvents() {
 
 	go func() {
 		for msg := range b.client.Event() {
+			b.trackCrash(msg)
 			b.event.Publish(msg)
 		}
 	}()
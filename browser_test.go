@@ -33,6 +33,19 @@ func (s *S) TestIncognito() {
 	s.EqualValues(1, page.MustEval(`k => localStorage[k]`, k).Int())
 }
 
+func (s *S) TestIncognitoClose() {
+	b := s.browser.MustIncognito()
+	page := b.MustPage(srcFile("fixtures/click.html"))
+
+	utils.E(b.Close())
+
+	for _, p := range s.browser.MustPages() {
+		s.NotEqual(page.TargetID, p.TargetID)
+	}
+	s.Error(lastE(b.Page("")))
+	s.NotNil(s.page.MustInfo()) // the original browser is still alive
+}
+
 func (s *S) TestPageErr() {
 	s.Panics(func() {
 		s.mc.stubErr(1, proto.TargetAttachToTarget{})
@@ -89,6 +102,22 @@ func (s *S) TestBrowserPages() {
 	})
 }
 
+func (s *S) TestBrowserPagesClose() {
+	ad := s.browser.MustPage(srcFile("fixtures/click.html")).MustWaitLoad()
+	count := len(s.browser.MustPages())
+
+	var found *rod.Page
+	for _, p := range s.browser.MustPages() {
+		if p.TargetID == ad.TargetID {
+			found = p
+		}
+	}
+	s.NotNil(found)
+
+	found.MustClose()
+	s.Len(s.browser.MustPages(), count-1)
+}
+
 func (s *S) TestBrowserClearStates() {
 	utils.E(proto.EmulationClearGeolocationOverride{}.Call(s.page))
 
@@ -165,7 +194,11 @@ func (s *S) TestMonitorErr() {
 
 func (s *S) TestTrace() {
 	var msg *rod.TraceMsg
-	s.browser.TraceLog(func(m *rod.TraceMsg) { msg = m })
+	details := []interface{}{}
+	s.browser.TraceLog(func(m *rod.TraceMsg) {
+		msg = m
+		details = append(details, m.Details)
+	})
 	s.browser.Trace(true).Slowmotion(time.Microsecond)
 	defer func() {
 		s.browser.TraceLog(nil)
@@ -177,8 +210,17 @@ func (s *S) TestTrace() {
 	el.MustClick()
 
 	s.Equal(rod.TraceTypeInput, msg.Type)
-	s.Equal("left click", msg.Details)
-	s.Equal(`[input] "left click"`, msg.String())
+	s.Contains(details, "left click")
+	s.Regexp(`^left click at \(\d+\.\d{2}, \d+\.\d{2}\)$`, msg.Details)
+	s.Regexp(`^\[input\] "left click at `, msg.String())
+	s.Len(p.MustElements("body > div"), 0) // the overlays of the click point should be removed
+
+	p.Mouse.MustScroll(0, 10)
+	s.Regexp(`^scroll \(0\.00, 10\.00\) at `, msg.Details)
+	p.Keyboard.MustPress('a')
+	s.Equal("press a", msg.Details)
+	p.Mouse.MustMove(10, 20)
+	s.Equal("move to (10.00, 20.00)", msg.Details)
 
 	s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
 	_ = p.Mouse.Move(10, 10, 1)
@@ -126,6 +126,55 @@ func (p *Page) Overlay(left, top, width, height float64, msg string) (remove fun
 	return
 }
 
+// StartInspect enters the inspect mode of the devtools, each element the user clicks will be sent to picked.
+// Call stop to exit the inspect mode and close the channel.
+func (p *Page) StartInspect() (picked <-chan *Element, stop func(), err error) {
+	ctx, cancel := context.WithCancel(p.ctx)
+	ch := make(chan *Element)
+
+	inspect := proto.OverlaySetInspectMode{
+		Mode: proto.OverlayInspectModeSearchForNode,
+		HighlightConfig: &proto.OverlayHighlightConfig{
+			ShowInfo:     true,
+			ContentColor: &proto.DOMRGBA{R: 111, G: 168, B: 220, A: 0.66},
+			PaddingColor: &proto.DOMRGBA{R: 147, G: 196, B: 125, A: 0.55},
+			MarginColor:  &proto.DOMRGBA{R: 246, G: 178, B: 107, A: 0.66},
+		},
+	}
+
+	wait := p.Context(ctx).EachEvent(func(e *proto.OverlayInspectNodeRequested) {
+		obj, err := proto.DOMResolveNode{BackendNodeID: e.BackendNodeID}.Call(p)
+		if err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case ch <- p.ElementFromObject(obj.Object.ObjectID):
+		}
+
+		// the browser may quit the inspect mode after a node is picked
+		_ = inspect.Call(p)
+	})
+
+	err = inspect.Call(p)
+	if err != nil {
+		cancel()
+		wait()
+		return nil, nil, err
+	}
+
+	go func() {
+		defer close(ch)
+		wait()
+	}()
+
+	return ch, func() {
+		_ = proto.OverlaySetInspectMode{Mode: proto.OverlayInspectModeNone}.Call(p)
+		cancel()
+	}, nil
+}
+
 // ExposeJSHelper to page's window object, so you can debug helper.js in the browser console.
 // Such as run `rod.elementR("div", "ok")` in the browser console to test the Page.ElementR.
 func (p *Page) ExposeJSHelper() *Page {
@@ -172,6 +221,18 @@ func (el *Element) tryTraceInput(details string) func() {
 
 var regHelperJS = regexp.MustCompile(`\A\(rod, \.\.\.args\) => (rod\..+)\.apply\(this, `)
 
+// tryTraceInput logs the input action of the page devices, such as the mouse, keyboard, and touch,
+// and shows an overlay of it on the page.
+func (p *Page) tryTraceInput(details string) func() {
+	if !p.browser.trace {
+		return func() {}
+	}
+
+	p.browser.traceLog(&TraceMsg{TraceTypeInput, details})
+
+	return p.Overlay(0, 0, 200, 0, details)
+}
+
 func (p *Page) tryTraceEval(js string, params JSArgs) func() {
 	if !p.browser.trace {
 		return func() {}
@@ -230,6 +291,17 @@ func defaultTraceLog(msg *TraceMsg) {
 	log.Println(msg)
 }
 
+// marks the point where the mouse is going to click, so that we can see where exactly the click happens
+// on the screen or the screenshots taken during the slowmotion
+func (m *Mouse) tryTraceClickPoint() func() {
+	if !m.page.browser.trace {
+		return func() {}
+	}
+
+	const size = 10
+	return m.page.Overlay(m.x-size/2, m.y-size/2, size, size, "")
+}
+
 func (m *Mouse) initMouseTracer() {
 	_, _ = m.page.EvalWithOptions(jsHelper(js.InitMouseTracer, JSArgs{m.id, assets.MousePointer}))
 }
@@ -241,3 +313,28 @@ func (m *Mouse) updateMouseTracer() bool {
 	}
 	return res.Value.Bool()
 }
+
+// ShowMouseTrail enables or disables drawing the path of the mouse on the page, so that you can watch where
+// the automated cursor actually travels, such as to debug the flaky clicks. Disabling it removes the path.
+func (p *Page) ShowMouseTrail(enabled bool) *Page {
+	m := p.Mouse
+	m.Lock()
+	defer m.Unlock()
+
+	m.trail = enabled
+	if enabled {
+		m.updateMouseTrail()
+	} else {
+		_, _ = m.page.EvalWithOptions(jsHelper(js.RemoveOverlay, JSArgs{m.trailID()}))
+	}
+
+	return p
+}
+
+func (m *Mouse) trailID() string {
+	return m.id + "-trail"
+}
+
+func (m *Mouse) updateMouseTrail() {
+	_, _ = m.page.EvalWithOptions(jsHelper(js.UpdateMouseTrail, JSArgs{m.trailID(), m.x, m.y}))
+}
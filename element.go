@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -13,9 +15,9 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/go-rod/rod/lib/assets/js"
-	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
+	"github.com/pkg/errors"
 )
 
 // Element represents the DOM element
@@ -39,6 +41,12 @@ func (el *Element) Focus() error {
 	return err
 }
 
+// FocusNoScroll sets focus on the specified element without scrolling the page
+func (el *Element) FocusNoScroll() error {
+	_, err := el.EvalWithOptions(NewEvalOptions(`this.focus({ preventScroll: true })`, nil).ByUser())
+	return err
+}
+
 // ScrollIntoView scrolls the current element into the visible area of the browser
 // window if it's not already within the visible area.
 func (el *Element) ScrollIntoView() error {
@@ -48,6 +56,29 @@ func (el *Element) ScrollIntoView() error {
 	return proto.DOMScrollIntoViewIfNeeded{ObjectID: el.ObjectID}.Call(el)
 }
 
+// ScrollPercentY returns the vertical scroll position of the element normalized to 0..1,
+// 0 will be returned if the element isn't scrollable.
+func (el *Element) ScrollPercentY() (float64, error) {
+	res, err := el.Eval(`() => {
+		const max = this.scrollHeight - this.clientHeight
+		return max > 0 ? this.scrollTop / max : 0
+	}`)
+	if err != nil {
+		return 0, err
+	}
+	return res.Value.Num, nil
+}
+
+// ScrollToPercentY scrolls the element vertically to the position normalized to 0..1,
+// such as 0.8 to trigger the "scrolled to 80%" logic. Check ScrollPercentY for details.
+func (el *Element) ScrollToPercentY(percent float64) error {
+	defer el.tryTraceInput(fmt.Sprintf("scroll to %.0f%%", percent*100))()
+	el.page.browser.trySlowmotion()
+
+	_, err := el.Eval(`p => this.scrollTop = p * (this.scrollHeight - this.clientHeight)`, percent)
+	return err
+}
+
 // Hover the mouse over the center of the element.
 func (el *Element) Hover() error {
 	err := el.WaitVisible()
@@ -65,7 +96,7 @@ func (el *Element) Hover() error {
 		return err
 	}
 
-	err = el.page.Mouse.Move(shape[0].CenterX(), shape[0].CenterY(), 1)
+	err = el.page.Mouse.Move(shape[0].CenterX(), shape[0].CenterY(), 0)
 	if err != nil {
 		return err
 	}
@@ -73,6 +104,60 @@ func (el *Element) Hover() error {
 	return nil
 }
 
+// HoverFor hovers the element and keeps the mouse over it for d, such as to show the delayed tooltips.
+// A tiny move is dispatched every 100ms to keep the "mousemove" based timers of the page running.
+func (el *Element) HoverFor(d time.Duration) error {
+	err := el.Hover()
+	if err != nil {
+		return err
+	}
+
+	m := el.page.Mouse
+	m.Lock()
+	x, y := m.x, m.y
+	m.Unlock()
+
+	end := time.NewTimer(d)
+	defer end.Stop()
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+
+	for i := 1; ; i++ {
+		select {
+		case <-end.C:
+			return nil
+		case <-el.ctx.Done():
+			return el.ctx.Err()
+		case <-t.C:
+		}
+
+		// move back and forth between the hover point and the point next to it
+		err = m.Move(x+float64(i%2), y, 1)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Leave moves the mouse out of the element's box to fire the "mouseleave" and "mouseout" events.
+func (el *Element) Leave() error {
+	box, err := el.Box()
+	if err != nil {
+		return err
+	}
+
+	x := box.Border.X() - 1
+	y := box.Border.Y() - 1
+	if x < 0 || y < 0 {
+		x = box.Border.X() + box.Border.Width() + 1
+		y = box.Border.Y() + box.Border.Height() + 1
+	}
+
+	defer el.tryTraceInput("leave")()
+
+	return el.page.Mouse.Move(x, y, 0)
+}
+
 // Click will press then release the button just like a human.
 func (el *Element) Click(button proto.InputMouseButton) error {
 	err := el.Hover()
@@ -85,6 +170,70 @@ func (el *Element) Click(button proto.InputMouseButton) error {
 	return el.page.Mouse.Click(button)
 }
 
+// ClickJS calls the "click" method of the element via js, it works even if the element is covered by others.
+// No mouse event will be dispatched, use it only when Click returns ErrNotInteractable.
+func (el *Element) ClickJS() error {
+	defer el.tryTraceInput("js click")()
+
+	_, err := el.Eval(`this.click()`)
+	return err
+}
+
+// ClickAndWaitNav clicks the element, then waits until the new document of the main frame fires the load event.
+// The wait starts before the click, so it won't miss the navigation triggered by the click.
+func (el *Element) ClickAndWaitNav(button proto.InputMouseButton) error {
+	ctx, cancel := context.WithCancel(el.ctx)
+	defer cancel()
+
+	navigated := false
+	wait := el.page.Context(ctx).EachEvent(func(e *proto.PageFrameNavigated) {
+		navigated = navigated || e.Frame.ParentID == ""
+	}, func(e *proto.PageLoadEventFired) bool {
+		return navigated
+	})
+
+	err := el.Click(button)
+	if err != nil {
+		// the wait returns immediately after the cancel, it restores the domains and unsubscribes
+		cancel()
+		wait()
+		return err
+	}
+
+	wait()
+
+	return el.ctx.Err()
+}
+
+// DoubleClickSelect double clicks the center of the element with the left button, such as to select a word,
+// then returns the selected text of the page.
+func (el *Element) DoubleClickSelect() (string, error) {
+	err := el.Hover()
+	if err != nil {
+		return "", err
+	}
+
+	defer el.tryTraceInput("double click select")()
+
+	mouse := el.page.Mouse
+	for clicks := int64(1); clicks <= 2; clicks++ {
+		err = mouse.Down(proto.InputMouseButtonLeft, clicks)
+		if err != nil {
+			return "", err
+		}
+		err = mouse.Up(proto.InputMouseButtonLeft, clicks)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	res, err := el.page.Eval(`window.getSelection().toString()`)
+	if err != nil {
+		return "", err
+	}
+	return res.Value.String(), nil
+}
+
 // Tap the button just like a human.
 func (el *Element) Tap() error {
 	err := el.WaitVisible()
@@ -120,14 +269,25 @@ func (el *Element) Interactable() (shape []proto.DOMQuad, err error) {
 		return
 	}
 
-	scroll, err := el.page.Root().Eval(`{ x: window.scrollX, y: window.scrollY }`)
+	// The shape is relative to the visual viewport, under device emulation the visual viewport
+	// may be scaled, such as the mobile layout, so we have to convert the point to the page coordinates.
+	vp, err := el.page.Root().Eval(`{
+		x: window.visualViewport.pageLeft,
+		y: window.visualViewport.pageTop,
+		scale: window.visualViewport.scale,
+	}`)
 	if err != nil {
 		return
 	}
 
+	scale := vp.Value.Get("scale").Float()
+	if scale == 0 {
+		scale = 1
+	}
+
 	elAtPoint, err := el.page.ElementFromPoint(
-		int64(shape[0].CenterX())+scroll.Value.Get("x").Int(),
-		int64(shape[0].CenterY())+scroll.Value.Get("y").Int(),
+		int64(shape[0].CenterX()/scale+vp.Value.Get("x").Float()),
+		int64(shape[0].CenterY()/scale+vp.Value.Get("y").Float()),
 	)
 	if err != nil {
 		return
@@ -169,6 +329,19 @@ func (el *Element) Box() (*proto.DOMBoxModel, error) {
 	return res.Model, nil
 }
 
+// WaitBox polls the box model until both of its width and height are non-zero, such as to wait
+// for the layout of a freshly inserted element. Use Element.Timeout to limit the waiting time.
+func (el *Element) WaitBox() (box *proto.DOMBoxModel, err error) {
+	err = utils.Retry(el.ctx, el.sleeper(), func() (bool, error) {
+		box, err = el.Box()
+		if err != nil {
+			return true, err
+		}
+		return box.Width > 0 && box.Height > 0, nil
+	})
+	return
+}
+
 // Press a key
 func (el *Element) Press(key rune) error {
 	err := el.WaitVisible()
@@ -181,12 +354,44 @@ func (el *Element) Press(key rune) error {
 		return err
 	}
 
-	defer el.tryTraceInput("press " + input.Keys[key].Key)()
+	defer el.tryTraceInput("press " + keyName(key))()
 
 	return el.page.Keyboard.Press(key)
 }
 
-// SelectText selects the text that matches the regular expression
+// PressRepeat holds the key down and repeats it, check Keyboard.PressRepeat for details.
+func (el *Element) PressRepeat(key rune, count int, interval time.Duration) error {
+	err := el.WaitVisible()
+	if err != nil {
+		return err
+	}
+
+	err = el.Focus()
+	if err != nil {
+		return err
+	}
+
+	return el.page.Keyboard.PressRepeat(key, count, interval)
+}
+
+// PressWithModifiers presses the key while holding the modifiers, such as el.PressWithModifiers(input.Tab, input.Shift).
+// Check Keyboard.PressWithModifiers for details.
+func (el *Element) PressWithModifiers(key rune, modifiers ...rune) error {
+	err := el.WaitVisible()
+	if err != nil {
+		return err
+	}
+
+	err = el.Focus()
+	if err != nil {
+		return err
+	}
+
+	return el.page.Keyboard.PressWithModifiers(key, modifiers...)
+}
+
+// SelectText selects the text that matches the regular expression.
+// It works for both the input-like elements and the elements with text, such as the contenteditable elements.
 func (el *Element) SelectText(regex string) error {
 	err := el.Focus()
 	if err != nil {
@@ -200,7 +405,30 @@ func (el *Element) SelectText(regex string) error {
 	return err
 }
 
-// SelectAllText selects all text
+// SelectionRect returns the bounding rect of the current selection relative to the viewport, or nil if nothing
+// is selected. For the input-like elements the rect of the element will be returned.
+func (el *Element) SelectionRect() (*proto.DOMRect, error) {
+	opts := jsHelper(js.SelectionRect, nil)
+	opts.ByValue = true
+	res, err := el.EvalWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Value.Type == gjson.Null {
+		return nil, nil
+	}
+
+	return &proto.DOMRect{
+		X:      res.Value.Get("x").Float(),
+		Y:      res.Value.Get("y").Float(),
+		Width:  res.Value.Get("width").Float(),
+		Height: res.Value.Get("height").Float(),
+	}, nil
+}
+
+// SelectAllText selects all text.
+// It works for both the input-like elements and the contenteditable elements.
 func (el *Element) SelectAllText() error {
 	err := el.Focus()
 	if err != nil {
@@ -214,9 +442,48 @@ func (el *Element) SelectAllText() error {
 	return err
 }
 
+// ClearRichText empties the contenteditable element like a user, then fires the "input" event.
+func (el *Element) ClearRichText() error {
+	err := el.Focus()
+	if err != nil {
+		return err
+	}
+
+	defer el.tryTraceInput("clear rich text")()
+	el.page.browser.trySlowmotion()
+
+	_, err = el.EvalWithOptions(jsHelper(js.ClearRichText, nil).ByUser())
+	return err
+}
+
+// SelectRange selects the text between the start and end indexes of the element's text.
+// It works for both the input-like elements and the contenteditable elements.
+func (el *Element) SelectRange(start, end int) error {
+	err := el.Focus()
+	if err != nil {
+		return err
+	}
+
+	defer el.tryTraceInput(fmt.Sprintf("select range: %d, %d", start, end))()
+	el.page.browser.trySlowmotion()
+
+	_, err = el.EvalWithOptions(jsHelper(js.SelectRange, JSArgs{start, end}).ByUser())
+	return err
+}
+
 // Input focus the element and input text to it.
 // To empty the input you can use something like el.SelectAllText().MustInput("")
 func (el *Element) Input(text string) error {
+	return el.input(text, text)
+}
+
+// InputSecret is similar to Input, but the text won't be shown in the trace, "****" will be shown instead.
+// Use it to input passwords or other credentials.
+func (el *Element) InputSecret(text string) error {
+	return el.input(text, "****")
+}
+
+func (el *Element) input(text, traceText string) error {
 	err := el.WaitVisible()
 	if err != nil {
 		return err
@@ -227,9 +494,9 @@ func (el *Element) Input(text string) error {
 		return err
 	}
 
-	defer el.tryTraceInput("input " + text)()
+	defer el.tryTraceInput("input " + traceText)()
 
-	err = el.page.Keyboard.InsertText(text)
+	err = el.page.Keyboard.insertText(text, traceText)
 	if err != nil {
 		return err
 	}
@@ -244,6 +511,21 @@ func (el *Element) Blur() error {
 	return err
 }
 
+// SetValue sets the value of the form element, then dispatches the input and change events.
+// It's useful for the inputs that are hard to type into, such as the range or date input.
+func (el *Element) SetValue(value string) error {
+	err := el.WaitVisible()
+	if err != nil {
+		return err
+	}
+
+	defer el.tryTraceInput(fmt.Sprintf(`set value "%s"`, value))()
+	el.page.browser.trySlowmotion()
+
+	_, err = el.EvalWithOptions(jsHelper(js.SetValue, JSArgs{value}).ByUser())
+	return err
+}
+
 // Select the children option elements that match the selectors, the selector can be text content or css selector
 func (el *Element) Select(selectors []string) error {
 	err := el.WaitVisible()
@@ -267,6 +549,20 @@ func (el *Element) Matches(selector string) (bool, error) {
 	return res.Value.Bool(), nil
 }
 
+// MatchesAny returns the first selector in the list that can select the element.
+// If none of them matches, an empty string will be returned.
+func (el *Element) MatchesAny(selectors []string) (string, error) {
+	if len(selectors) == 0 {
+		return "", nil
+	}
+
+	res, err := el.Eval(`list => list.find(s => this.matches(s)) || ''`, selectors)
+	if err != nil {
+		return "", err
+	}
+	return res.Value.String(), nil
+}
+
 // Attribute is similar to the method Attribute
 func (el *Element) Attribute(name string) (*string, error) {
 	attr, err := el.Eval("(n) => this.getAttribute(n)", name)
@@ -281,6 +577,21 @@ func (el *Element) Attribute(name string) (*string, error) {
 	return &attr.Value.Str, nil
 }
 
+// AttributeNS is similar to Attribute, but for the attribute with the namespace, such as the "xlink:href" of svg.
+// The name is the local name without the prefix.
+func (el *Element) AttributeNS(ns, name string) (*string, error) {
+	attr, err := el.Eval("(ns, n) => this.getAttributeNS(ns, n)", ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if attr.Value.Type == gjson.Null {
+		return nil, nil
+	}
+
+	return &attr.Value.Str, nil
+}
+
 // Property is similar to the method Property
 func (el *Element) Property(name string) (proto.JSON, error) {
 	prop, err := el.Eval("(n) => this[n]", name)
@@ -311,6 +622,56 @@ func (el *Element) SetFiles(paths []string) error {
 	return err
 }
 
+// NamedReader is a file with its name and content, check Element.SetFilesFromReaders
+type NamedReader struct {
+	Name   string
+	Reader io.Reader
+}
+
+// SetFilesFromReaders is similar to SetFiles, but the files are created from in-memory data,
+// so you don't have to write temp files first. The data will be sent to the browser as base64.
+func (el *Element) SetFilesFromReaders(files []NamedReader) error {
+	type file struct {
+		Name string `json:"name"`
+		Data string `json:"data"`
+	}
+
+	list := []file{}
+	names := []string{}
+	for _, f := range files {
+		b, err := ioutil.ReadAll(f.Reader)
+		if err != nil {
+			return err
+		}
+		list = append(list, file{f.Name, base64.StdEncoding.EncodeToString(b)})
+		names = append(names, f.Name)
+	}
+
+	defer el.tryTraceInput(fmt.Sprintf("set files: %v", names))()
+	el.page.browser.trySlowmotion()
+
+	_, err := el.EvalWithOptions(jsHelper(js.SetFiles, JSArgs{list}))
+	return err
+}
+
+// WaitFileRead returns a wait function that waits until the "change" event of the file input is fired
+// and count files are selected. Call it before SetFiles.
+func (el *Element) WaitFileRead(count int) func() error {
+	_, err := el.Eval(`() => {
+		this.rodFileRead = new Promise((r) => this.addEventListener('change', r, { once: true }))
+	}`)
+
+	return func() error {
+		if err != nil {
+			return err
+		}
+		return el.Wait(`async (count) => {
+			await this.rodFileRead
+			return this.files.length === count
+		}`, count)
+	}
+}
+
 // Describe the current element
 func (el *Element) Describe(depth int, pierce bool) (*proto.DOMNode, error) {
 	val, err := proto.DOMDescribeNode{ObjectID: el.ObjectID, Depth: int64(depth), Pierce: pierce}.Call(el)
@@ -320,6 +681,15 @@ func (el *Element) Describe(depth int, pierce bool) (*proto.DOMNode, error) {
 	return val.Node, nil
 }
 
+// DescribeAttributes returns the attributes of the element as a map, it's based on Describe
+func (el *Element) DescribeAttributes() (map[string]string, error) {
+	node, err := el.Describe(0, false)
+	if err != nil {
+		return nil, err
+	}
+	return node.AttributeMap(), nil
+}
+
 // NodeID of the node
 func (el *Element) NodeID() (proto.DOMNodeID, error) {
 	el.page.enableNodeQuery()
@@ -330,13 +700,64 @@ func (el *Element) NodeID() (proto.DOMNodeID, error) {
 	return node.NodeID, nil
 }
 
-// ShadowRoot returns the shadow root of this element
+// AXNode returns the accessibility node of the element
+func (el *Element) AXNode() (*proto.AccessibilityAXNode, error) {
+	node, err := el.Describe(0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := proto.AccessibilityGetPartialAXTree{ObjectID: el.ObjectID}.Call(el)
+	if err != nil {
+		return nil, err
+	}
+
+	// the result contains the relatives of the node, such as the ancestors
+	for _, n := range res.Nodes {
+		if n.BackendDOMNodeID == node.BackendNodeID {
+			return n, nil
+		}
+	}
+
+	return nil, newErr(ErrAXNodeNotFound, node, "the element is not in the accessibility tree")
+}
+
+// AXRole returns the computed ARIA role of the element, such as "button"
+func (el *Element) AXRole() (string, error) {
+	node, err := el.AXNode()
+	if err != nil {
+		return "", err
+	}
+	if node.Role == nil {
+		return "", nil
+	}
+	return node.Role.Value.String(), nil
+}
+
+// AXName returns the computed accessible name of the element, such as the label of a button
+func (el *Element) AXName() (string, error) {
+	node, err := el.AXNode()
+	if err != nil {
+		return "", err
+	}
+	if node.Name == nil {
+		return "", nil
+	}
+	return node.Name.Value.String(), nil
+}
+
+// ShadowRoot returns the shadow root of this element.
+// If the element doesn't host a shadow root, ErrNoShadowRoot will be returned.
 func (el *Element) ShadowRoot() (*Element, error) {
 	node, err := el.Describe(1, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(node.ShadowRoots) == 0 {
+		return nil, newErr(ErrNoShadowRoot, node, node.NodeName)
+	}
+
 	// though now it's an array, w3c changed the spec of it to be a single.
 	id := node.ShadowRoots[0].BackendNodeID
 
@@ -358,11 +779,25 @@ func (el *Element) Frame() (*Page, error) {
 	newPage := *el.page
 	newPage.FrameID = node.FrameID
 	newPage.element = el
-	newPage.jsHelperObjectID = ""
-	newPage.windowObjectID = ""
+	newPage.jsContext = &jsContext{}
 	return &newPage, nil
 }
 
+// FrameReady is similar to Frame, but it waits until the document of the iframe is loaded before returning,
+// so that the returned page can be used immediately.
+func (el *Element) FrameReady() (*Page, error) {
+	frame, err := el.Frame()
+	if err != nil {
+		return nil, err
+	}
+
+	err = frame.WaitLoad()
+	if err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
 // ContainsElement check if the target is equal or inside the element.
 func (el *Element) ContainsElement(target *Element) (bool, error) {
 	res, err := el.EvalWithOptions(jsHelper(js.ContainsElement, JSArgs{target.ObjectID}))
@@ -381,6 +816,18 @@ func (el *Element) Text() (string, error) {
 	return str.Value.String(), nil
 }
 
+// OwnText returns the text of the direct child text nodes of the element, the text of the descendants is excluded.
+func (el *Element) OwnText() (string, error) {
+	str, err := el.Eval(`() => Array.from(this.childNodes)
+		.filter(n => n.nodeType === Node.TEXT_NODE)
+		.map(n => n.textContent)
+		.join('')`)
+	if err != nil {
+		return "", err
+	}
+	return str.Value.String(), nil
+}
+
 // HTML of the element
 func (el *Element) HTML() (string, error) {
 	str, err := el.Eval(`this.outerHTML`)
@@ -390,6 +837,19 @@ func (el *Element) HTML() (string, error) {
 	return str.Value.String(), nil
 }
 
+// IsStale returns true if the element is removed from the document or its remote object is gone,
+// such as after the navigation of the page. Then you should query the element again.
+func (el *Element) IsStale() (bool, error) {
+	res, err := el.Eval(`this.isConnected`)
+	if err != nil {
+		if isNilContextErr(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return !res.Value.Bool(), nil
+}
+
 // Visible returns true if the element is visible on the page
 func (el *Element) Visible() (bool, error) {
 	res, err := el.EvalWithOptions(jsHelper(js.Visible, nil))
@@ -399,23 +859,84 @@ func (el *Element) Visible() (bool, error) {
 	return res.Value.Bool(), nil
 }
 
+// Perceivable returns true if the element is visible and its effective opacity isn't 0,
+// the opacity of each ancestor is checked too, such as a fade-in element before the animation.
+func (el *Element) Perceivable() (bool, error) {
+	res, err := el.EvalWithOptions(jsHelper(js.Perceivable, nil))
+	if err != nil {
+		return false, err
+	}
+	return res.Value.Bool(), nil
+}
+
+// IntersectionRatio returns how much of the element is inside the viewport, from 0 to 1.
+// It's the IntersectionObserverEntry.intersectionRatio, such as 0.5 means half of the element is visible.
+func (el *Element) IntersectionRatio() (float64, error) {
+	res, err := el.EvalWithOptions(jsHelper(js.IntersectionRatio, nil))
+	if err != nil {
+		return 0, err
+	}
+	return res.Value.Float(), nil
+}
+
 // WaitLoad for element like <img>
 func (el *Element) WaitLoad() error {
 	_, err := el.EvalWithOptions(jsHelper(js.WaitLoad, nil))
 	return err
 }
 
+// WaitStable waits until both the shape and the computed opacity of the element stop changing within the interval.
 // WaitStable not using requestAnimation here because it can trigger to many checks,
 // or miss checks for jQuery css animation.
 func (el *Element) WaitStable(interval time.Duration) error {
+	_, err := el.WaitStableShape(interval)
+	return err
+}
+
+// WaitStableShape is similar to WaitStable, but returns the final stable shape of the element,
+// so that you don't have to call Shape again after the waiting.
+func (el *Element) WaitStableShape(interval time.Duration) ([]proto.DOMQuad, error) {
+	return el.waitStable(interval, func(a, b []proto.DOMQuad) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
+
+// WaitStableThreshold is similar to WaitStable, but each corner of the quads only needs to move less than
+// the pixels between two checks, it tolerates the sub-pixel jitter of smooth css animations.
+func (el *Element) WaitStableThreshold(interval time.Duration, pixels float64) error {
+	_, err := el.waitStable(interval, func(a, b []proto.DOMQuad) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if len(a[i]) != len(b[i]) {
+				return false
+			}
+			for j := range a[i] {
+				if math.Abs(a[i][j]-b[i][j]) >= pixels {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return err
+}
+
+func (el *Element) waitStable(interval time.Duration, same func(a, b []proto.DOMQuad) bool) ([]proto.DOMQuad, error) {
 	err := el.WaitVisible()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	shape, err := el.Shape()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	opacity, err := el.opacity()
+	if err != nil {
+		return nil, err
 	}
 
 	t := time.NewTicker(interval)
@@ -425,24 +946,48 @@ func (el *Element) WaitStable(interval time.Duration) error {
 		select {
 		case <-t.C:
 		case <-el.ctx.Done():
-			return el.ctx.Err()
+			return nil, el.ctx.Err()
 		}
+
+		// the element may be replaced during the animation, no need to wait for a node that will never be stable
+		stale, err := el.IsStale()
+		if err != nil {
+			return nil, err
+		}
+		if stale {
+			return nil, newErr(ErrElementDetached, el, "the element is removed from the document")
+		}
+
 		current, err := el.Shape()
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if reflect.DeepEqual(shape, current) {
+		currentOpacity, err := el.opacity()
+		if err != nil {
+			return nil, err
+		}
+		if same(shape, current) && opacity == currentOpacity {
 			break
 		}
 		shape = current
+		opacity = currentOpacity
 	}
-	return nil
+	return shape, nil
+}
+
+// the computed opacity, fade in or fade out animations don't change the shape of the element
+func (el *Element) opacity() (string, error) {
+	res, err := el.Eval(`() => getComputedStyle(this).opacity`)
+	if err != nil {
+		return "", err
+	}
+	return res.Value.String(), nil
 }
 
 // Wait until the js returns true
 func (el *Element) Wait(js string, params ...interface{}) error {
 	return utils.Retry(el.ctx, el.sleeper(), func() (bool, error) {
-		res, err := el.Eval(js, params...)
+		res, err := el.EvalWithOptions(NewEvalOptions(js, params).WithTerminate())
 		if err != nil {
 			return true, err
 		}
@@ -455,9 +1000,28 @@ func (el *Element) Wait(js string, params ...interface{}) error {
 	})
 }
 
-// WaitVisible until the element is visible
+// WaitVisible until the element is visible. If the context of the element ends first, the error will tell
+// why the element is still invisible.
 func (el *Element) WaitVisible() error {
 	opts := jsHelper(js.Visible, nil)
+	err := el.Wait(opts.JS, opts.JSArgs...)
+	if err == nil || el.ctx.Err() == nil {
+		return err
+	}
+
+	// the context of the element is done, check one more time with a short timeout in case the page hangs
+	ctx, cancel := context.WithTimeout(el.page.browser.ctx, time.Second)
+	defer cancel()
+	res, e := el.Context(ctx).EvalWithOptions(jsHelper(js.InvisibleReason, nil))
+	if e != nil || res.Value.Str == "" {
+		return err
+	}
+	return errors.WithMessage(err, "the element is invisible because of "+res.Value.Str)
+}
+
+// WaitPerceivable until the element is perceivable, check Element.Perceivable for details
+func (el *Element) WaitPerceivable() error {
+	opts := jsHelper(js.Perceivable, nil)
 	return el.Wait(opts.JS, opts.JSArgs...)
 }
 
@@ -481,36 +1045,54 @@ func (el *Element) CanvasToImage(format string, quality float64) ([]byte, error)
 	return bin, nil
 }
 
-// Resource returns the "src" content of current element. Such as the jpg of <img src="a.jpg">
+// Resource returns the "src" content of current element. Such as the jpg of <img src="a.jpg">.
+// If the element has no "src", such as a <div>, the url of its computed css background-image will be used.
 func (el *Element) Resource() ([]byte, error) {
 	src, err := el.EvalWithOptions(jsHelper(js.Resource, nil))
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := proto.PageGetResourceContent{
-		FrameID: el.page.FrameID,
-		URL:     src.Value.String(),
-	}.Call(el)
-	if err != nil {
-		return nil, err
-	}
-
-	data := res.Content
+	return el.page.Context(el.ctx).GetResource(src.Value.String())
+}
 
-	var bin []byte
-	if res.Base64Encoded {
-		bin, err = base64.StdEncoding.DecodeString(data)
-		utils.E(err)
-	} else {
-		bin = []byte(data)
-	}
+// Screenshot of the area of the element, the quality is only used by the jpeg format.
+// If the element is larger than the viewport, the viewport will be temporarily expanded.
+func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
+	return el.screenshot(format, quality, func(content proto.DOMQuad) *proto.PageViewport {
+		return &proto.PageViewport{
+			X:      content.X(),
+			Y:      content.Y(),
+			Width:  content.Width(),
+			Height: content.Height(),
+			Scale:  1,
+		}
+	})
+}
 
-	return bin, nil
+// ScreenshotRegion is similar to Screenshot, but only captures the region in css pixels relative to
+// the content box of the element.
+func (el *Element) ScreenshotRegion(
+	x, y, width, height float64,
+	format proto.PageCaptureScreenshotFormat,
+	quality int,
+) ([]byte, error) {
+	return el.screenshot(format, quality, func(content proto.DOMQuad) *proto.PageViewport {
+		return &proto.PageViewport{
+			X:      content.X() + x,
+			Y:      content.Y() + y,
+			Width:  width,
+			Height: height,
+			Scale:  1,
+		}
+	})
 }
 
-// Screenshot of the area of the element
-func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
+func (el *Element) screenshot(
+	format proto.PageCaptureScreenshotFormat,
+	quality int,
+	getClip func(content proto.DOMQuad) *proto.PageViewport,
+) ([]byte, error) {
 	err := el.WaitVisible()
 	if err != nil {
 		return nil, err
@@ -525,19 +1107,49 @@ func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality
 	if err != nil {
 		return nil, err
 	}
+	clip := getClip(box.Content)
+
+	root := el.page.Root()
+
+	metrics, err := root.LayoutMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	if clip.Width > float64(metrics.LayoutViewport.ClientWidth) ||
+		clip.Height > float64(metrics.LayoutViewport.ClientHeight) {
+		restore, err := root.expandViewport()
+		if err != nil {
+			return nil, err
+		}
+		defer restore()
+
+		// the layout changes after the resizing
+		box, err = el.Box()
+		if err != nil {
+			return nil, err
+		}
+		clip = getClip(box.Content)
+	}
 
 	opts := &proto.PageCaptureScreenshot{
-		Format: format,
-		Clip: &proto.PageViewport{
-			X:      box.Content.X(),
-			Y:      box.Content.Y(),
-			Width:  box.Content.Width(),
-			Height: box.Content.Height(),
-			Scale:  1,
-		},
+		Format:  format,
+		Quality: int64(quality),
+		Clip:    clip,
 	}
 
-	return el.page.Root().Screenshot(false, opts)
+	return root.Screenshot(false, opts)
+}
+
+// ScreenshotWhenLoaded is similar to Screenshot, but it waits until the element and all its descendant images
+// are loaded before capturing, so that the images won't be blank in the screenshot.
+func (el *Element) ScreenshotWhenLoaded(format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
+	_, err := el.EvalWithOptions(jsHelper(js.WaitImagesLoad, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Screenshot(format, quality)
 }
 
 // Release the remote object reference
@@ -564,6 +1176,18 @@ func (el *Element) Eval(js string, params ...interface{}) (*proto.RuntimeRemoteO
 	return el.EvalWithOptions(NewEvalOptions(js, params))
 }
 
+// EvalShadow is similar to Eval, but the "this" of the js will be the shadow root of the element if it hosts one.
+func (el *Element) EvalShadow(js string, params ...interface{}) (*proto.RuntimeRemoteObject, error) {
+	root, err := el.ShadowRoot()
+	if errors.Is(err, ErrNoShadowRoot) {
+		return el.Eval(js, params...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return root.Context(el.ctx).Eval(js, params...)
+}
+
 // EvalWithOptions is just a shortcut of Page.EvalWithOptions with ThisID set to current element.
 func (el *Element) EvalWithOptions(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
 	return el.page.Context(el.ctx).EvalWithOptions(opts.This(el.ObjectID))
@@ -7,12 +7,18 @@ import (
 	"errors"
 	"image/color"
 	"image/png"
+	"net/http"
 	"path/filepath"
+	"strings"
+	"testing/iotest"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/input/layout"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/tidwall/gjson"
@@ -32,12 +38,80 @@ func (s *S) TestClick() {
 	})
 }
 
+func (s *S) TestClickJS() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+
+	// cover the button with a div
+	p.MustWaitLoad().MustEval(`() => {
+		let div = document.createElement('div')
+		div.style = 'position: absolute; left: 0; top: 0; width: 500px; height: 500px;'
+		document.body.append(div)
+	}`)
+	s.ErrorIs(el.Click(proto.InputMouseButtonLeft), rod.ErrNotInteractable)
+
+	el.MustClickJS()
+	s.True(p.MustHas("[a=ok]"))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustClickJS()
+	})
+}
+
+func (s *S) TestClickAndWaitNav() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	mux.HandleFunc("/", httpHTML(`<html><a href="/next">next</a><button>stay</button></html>`))
+	mux.HandleFunc("/next", httpHTML(`<html><title>next</title></html>`))
+
+	p := s.browser.MustPage(url).MustWaitLoad()
+	defer p.MustClose()
+
+	p.MustElement("a").MustClickAndWaitNav()
+	s.Equal("next", p.MustEval(`document.title`).String())
+
+	p.MustNavigate(url).MustWaitLoad()
+	btn := p.MustElement("button")
+	s.Error(btn.Timeout(300 * time.Millisecond).ClickAndWaitNav(proto.InputMouseButtonLeft))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		btn.MustClickAndWaitNav()
+	})
+}
+
 func (s *S) TestClickWrapped() {
 	p := s.page.MustNavigate(srcFile("fixtures/click-wrapped.html"))
 	p.MustElement("span").MustClick()
 	s.True(p.MustHas("[a=ok]"))
 }
 
+func (s *S) TestElementDoubleClickSelect() {
+	p := s.page.MustNavigate(srcFile("fixtures/double-click.html"))
+	el := p.MustElement("#word")
+	s.Equal("world", el.MustDoubleClickSelect())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+		el.MustDoubleClickSelect()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+		el.MustDoubleClickSelect()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(3, proto.InputDispatchMouseEvent{})
+		el.MustDoubleClickSelect()
+	})
+	p.Mouse.MustUp(proto.InputMouseButtonLeft)
+	s.Panics(func() {
+		s.mc.stubErr(5, proto.RuntimeCallFunctionOn{})
+		el.MustDoubleClickSelect()
+	})
+}
+
 func (s *S) TestTap() {
 	page := s.browser.MustPage("")
 	defer page.MustClose()
@@ -72,6 +146,20 @@ func (s *S) TestInteractable() {
 	s.True(p.MustElement("button").MustInteractable())
 }
 
+func (s *S) TestInteractableWithEmulation() {
+	page := s.browser.MustPage("")
+	defer page.MustClose()
+
+	// the click.html doesn't have the viewport meta, the mobile layout will be scaled
+	page.MustEmulate(devices.IPhoneX).MustNavigate(srcFile("fixtures/click.html")).MustWaitLoad()
+	s.Less(page.MustEval(`window.visualViewport.scale`).Float(), 1.0)
+
+	el := page.MustElement("button")
+	s.True(el.MustInteractable())
+	el.MustClick()
+	s.True(page.MustHas("[a=ok]"))
+}
+
 func (s *S) TestNotInteractable() {
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
 	el := p.MustElement("button")
@@ -108,6 +196,63 @@ func (s *S) TestNotInteractable() {
 	s.Error(lastE(el.Interactable()))
 }
 
+func (s *S) TestElementIsStale() {
+	p := s.browser.MustPage(srcFile("fixtures/click.html"))
+	defer p.MustClose()
+
+	el := p.MustElement("button")
+	s.False(el.MustIsStale())
+
+	h4 := p.MustElement("h4")
+	h4.MustEval(`this.remove()`)
+	s.True(h4.MustIsStale())
+
+	p.MustNavigate(srcFile("fixtures/input.html")).MustWaitLoad()
+	s.True(el.MustIsStale())
+
+	s.Panics(func() {
+		el := p.MustElement("textarea")
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustIsStale()
+	})
+
+	// only the errors about the gone object or context mean stale
+	el = p.MustElement("textarea")
+	s.mc.stub(1, proto.RuntimeCallFunctionOn{}, func(func() ([]byte, error)) ([]byte, error) {
+		return nil, &cdp.Error{Code: -32000, Message: "Argument should belong to the same JavaScript world as target object"}
+	})
+	_, err := el.IsStale()
+	s.Error(err)
+}
+
+func (s *S) TestScrollPercentY() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`document.body.insertAdjacentHTML('beforeend',
+		'<div id="list" style="height: 100px; overflow: auto"><div style="height: 500px"></div></div>')`)
+	el := p.MustElement("#list")
+
+	s.Equal(0.0, el.MustScrollPercentY())
+
+	el.MustScrollToPercentY(0.8)
+	s.EqualValues(320, el.MustEval(`this.scrollTop`).Int())
+	s.InDelta(0.8, el.MustScrollPercentY(), 0.01)
+
+	el.MustScrollToPercentY(1)
+	s.Equal(1.0, el.MustScrollPercentY())
+
+	// not scrollable
+	s.Equal(0.0, p.MustElement("h4").MustScrollPercentY())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustScrollPercentY()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustScrollToPercentY(0)
+	})
+}
+
 func (s *S) TestHover() {
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
 	el := p.MustElement("button")
@@ -125,6 +270,44 @@ func (s *S) TestHover() {
 	s.Error(el.Hover())
 }
 
+func (s *S) TestHoverFor() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+	el.MustEval(`() => {
+		this.moves = 0
+		this.onmousemove = () => this.moves++
+		this.onmouseenter = () => setTimeout(() => this.dataset['tip'] = 'shown', 300)
+	}`)
+
+	start := time.Now()
+	el.MustHoverFor(500 * time.Millisecond)
+	s.GreaterOrEqual(time.Since(start), 500*time.Millisecond)
+	s.Equal("shown", el.MustEval(`this.dataset['tip']`).String())
+	s.Greater(el.MustEval(`this.moves`).Int(), 2)
+
+	s.Error(el.Timeout(200 * time.Millisecond).HoverFor(time.Minute))
+
+	s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+	s.Error(el.HoverFor(time.Second))
+
+	s.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+	s.Error(el.HoverFor(time.Second))
+}
+
+func (s *S) TestLeave() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+	el.MustEval(`this.onmouseleave = () => this.dataset['a'] = 1`)
+	el.MustHover().MustLeave()
+	s.Equal("1", el.MustEval(`this.dataset['a']`).String())
+
+	s.mc.stubErr(1, proto.DOMGetBoxModel{})
+	s.Error(el.Leave())
+
+	s.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	s.Error(el.Leave())
+}
+
 func (s *S) TestMouseMoveErr() {
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
 	s.mc.stubErr(1, proto.InputDispatchMouseEvent{})
@@ -175,6 +358,33 @@ func (s *S) TestIframes() {
 	})
 }
 
+func (s *S) TestFrameReady() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	mux.HandleFunc("/", httpHTML(`<html><iframe src="/frame"></iframe></html>`))
+	mux.HandleFunc("/frame", httpHTML(`<html><img src="/slow"></html>`))
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		utils.Sleep(0.3)
+	})
+
+	p := s.browser.MustPage(url)
+	defer p.MustClose()
+
+	frame := p.MustElement("iframe").MustFrameReady()
+	s.Equal("complete", frame.MustEval(`document.readyState`).String())
+
+	el := p.MustElement("iframe")
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMDescribeNode{})
+		el.MustFrameReady()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageCreateIsolatedWorld{})
+		el.MustFrameReady()
+	})
+}
+
 func (s *S) TestContains() {
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
 	a := p.MustElement("button")
@@ -193,6 +403,9 @@ func (s *S) TestShadowDOM() {
 	el := p.MustElement("#container")
 	s.Equal("inside", el.MustShadowRoot().MustElement("p").MustText())
 
+	_, err := p.MustElement("body").ShadowRoot()
+	s.ErrorIs(err, rod.ErrNoShadowRoot)
+
 	s.Panics(func() {
 		s.mc.stubErr(1, proto.DOMDescribeNode{})
 		el.MustShadowRoot()
@@ -203,6 +416,36 @@ func (s *S) TestShadowDOM() {
 	})
 }
 
+func (s *S) TestEvalShadow() {
+	p := s.page.MustNavigate(srcFile("fixtures/shadow-dom.html")).MustWaitLoad()
+	el := p.MustElement("#container")
+	s.Equal("inside", el.MustEvalShadow(`s => this.querySelector(s).innerText`, "p").String())
+
+	// fallback to the element itself if there's no shadow root
+	s.Equal("BODY", p.MustElement("body").MustEvalShadow(`this.tagName`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMDescribeNode{})
+		el.MustEvalShadow(`1`)
+	})
+}
+
+func (s *S) TestQueryShadow() {
+	p := s.page.MustNavigate(srcFile("fixtures/shadow-dom-nested.html")).MustWaitLoad()
+	body := p.MustElement("body")
+	s.Equal("ok", body.MustQueryShadow("#container", ".inner", "button").MustText())
+	s.Equal(body, body.MustQueryShadow())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMDescribeNode{})
+		body.MustQueryShadow("#container", ".inner")
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		body.MustQueryShadow("#container")
+	})
+}
+
 func (s *S) TestPress() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("[type=text]")
@@ -212,6 +455,11 @@ func (s *S) TestPress() {
 
 	s.Equal("A b", el.MustText())
 
+	// the keys unknown to the keyboard will be inserted as text
+	el.MustPress('🎉')
+	p.Keyboard.MustDown('雲').MustUp('雲')
+	s.Equal("A b🎉雲", el.MustText())
+
 	s.Panics(func() {
 		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
 		el.MustPress(' ')
@@ -222,6 +470,173 @@ func (s *S) TestPress() {
 	})
 }
 
+func (s *S) TestSelectionRect() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`document.body.insertAdjacentHTML('beforeend', '<p>hello <b>big</b> world</p>')`)
+	el := p.MustElement("p")
+
+	el.MustSelectText(`big wo`)
+	s.Equal("big wo", p.MustEval(`getSelection().toString()`).String())
+
+	rect := el.MustSelectionRect()
+	box := el.MustEval(`this.getBoundingClientRect().toJSON()`)
+	s.Greater(rect.Width, 0.0)
+	s.Less(rect.Width, box.Get("width").Float())
+	s.Greater(rect.X, box.Get("x").Float())
+	s.InDelta(box.Get("y").Float(), rect.Y, 5)
+
+	p.MustEval(`getSelection().removeAllRanges()`)
+	s.Nil(el.MustSelectionRect())
+
+	textarea := p.MustNavigate(srcFile("fixtures/input.html")).MustElement("textarea")
+	s.Equal(textarea.MustEval(`this.getBoundingClientRect().width`).Float(), textarea.MustSelectionRect().Width)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		textarea.MustSelectionRect()
+	})
+}
+
+func (s *S) TestClearRichText() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`document.body.insertAdjacentHTML('beforeend', '<div contenteditable><p>ab<b>cd</b></p><p>ef<br></p></div>')`)
+	el := p.MustElement("[contenteditable]")
+	el.MustEval(`this.oninput = () => this.dataset.input = 'ok'`)
+
+	el.MustClearRichText()
+	s.Equal("", el.MustEval(`this.innerHTML`).String())
+	s.Equal("ok", el.MustEval(`this.dataset.input`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+		el.MustClearRichText()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+		el.MustClearRichText()
+	})
+}
+
+func (s *S) TestPressRepeat() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement("[type=text]")
+	el.MustEval(`() => {
+		this.repeats = []
+		this.onkeydown = e => this.repeats.push(e.repeat)
+	}`)
+
+	el.MustPressRepeat('a', 2, 10*time.Millisecond)
+	s.Equal("aaa", el.MustText())
+	s.Equal("[false,true,true]", el.MustEval(`this.repeats`).Raw)
+
+	el.MustPressRepeat('🎉', 1, 0)
+	s.Equal("aaa🎉🎉", el.MustText())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+		el.MustPressRepeat('a', 1, 0)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+		el.MustPressRepeat('a', 1, 0)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(3, proto.InputDispatchKeyEvent{})
+		el.MustPressRepeat('a', 1, 0)
+	})
+}
+
+func (s *S) TestPressWithModifiers() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement("[type=text]")
+	el.MustInput("abc")
+
+	// select the last char then replace it
+	el.MustPressWithModifiers(input.ArrowLeft, input.Shift)
+	el.MustInput("_")
+	s.Equal("ab_", el.MustText())
+
+	p.Keyboard.MustPressWithModifiers('🎉', input.Shift)
+	s.Equal("ab_🎉", el.MustText())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+		el.MustPressWithModifiers(input.Tab, input.Shift)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+		el.MustPressWithModifiers(input.Tab, input.Shift)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.InputDispatchKeyEvent{})
+		el.MustPressWithModifiers(input.Tab, input.Shift)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(4, proto.InputDispatchKeyEvent{})
+		el.MustPressWithModifiers(input.Tab, input.Shift)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputInsertText{})
+		p.Keyboard.MustPressWithModifiers('🎉', input.Shift)
+	})
+
+	s.ErrorIs(p.Keyboard.PressWithModifiers('a', '🎉'), rod.ErrInvalidModifier)
+	s.ErrorIs(p.Keyboard.PressWithModifiers('b', input.Enter), rod.ErrInvalidModifier)
+}
+
+func (s *S) TestPressWithModifiersRelease() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	p.MustEval(`() => {
+		window.released = []
+		document.addEventListener('keyup', e => released.push(e.key))
+	}`)
+
+	// the modifiers should be released even if pressing the key fails
+	s.mc.stubErr(3, proto.InputDispatchKeyEvent{})
+	s.Error(p.Keyboard.PressWithModifiers(input.Tab, input.Control, input.Shift))
+
+	s.Equal([]interface{}{"Shift", "Control"}, p.MustEval(`() => released`).Value())
+}
+
+func (s *S) TestInputSecret() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement("input")
+
+	logs := []string{}
+	s.browser.TraceLog(func(m *rod.TraceMsg) { logs = append(logs, m.String()) })
+	s.browser.Trace(true)
+	el.MustInputSecret("password")
+	s.browser.TraceLog(nil)
+	s.browser.Trace(defaults.Trace)
+
+	s.Equal("password", el.MustText())
+	s.Contains(logs, `[input] "input ****"`)
+	s.NotContains(strings.Join(logs, "\n"), "password")
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputInsertText{})
+		el.MustInputSecret("")
+	})
+}
+
+func (s *S) TestSelectRange() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement("textarea")
+	el.MustInput("abcdef")
+	el.MustSelectRange(1, 4)
+	el.MustInput("_")
+	s.Equal("a_ef", el.MustText())
+
+	p.MustEval(`document.body.insertAdjacentHTML('beforeend', '<div contenteditable>ab<b>cd</b>ef</div>')`)
+	p.MustElement("[contenteditable]").MustSelectRange(1, 5)
+	s.Equal("bcde", p.MustEval(`getSelection().toString()`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+		el.MustSelectRange(0, 1)
+	})
+}
+
 func (s *S) TestKeyDown() {
 	p := s.page.MustNavigate(srcFile("fixtures/keys.html"))
 	p.MustElement("body")
@@ -238,6 +653,26 @@ func (s *S) TestKeyUp() {
 	s.True(p.MustHas("body[event=key-up-x]"))
 }
 
+func (s *S) TestKeyboardLayout() {
+	p := s.page.MustNavigate(srcFile("fixtures/keys.html"))
+	p.MustElement("body")
+	p.MustEval(`window.onkeypress = (e) => document.body.setAttribute('code', e.code)`)
+
+	p.Keyboard.SetLayout(layout.German)
+	defer p.Keyboard.SetLayout(nil)
+
+	p.Keyboard.MustPress('z')
+	s.True(p.MustHas("body[event=key-up-z][code=KeyY]"))
+
+	p.Keyboard.MustPress('@')
+	s.True(p.MustHas("body[event=key-up-\\@][code=KeyQ]"))
+
+	// the keys not in the layout will fallback to the default layout
+	p.Keyboard.SetLayout(layout.Layout{})
+	p.Keyboard.MustPress('a')
+	s.True(p.MustHas("body[event=key-up-a][code=KeyA]"))
+}
+
 func (s *S) TestText() {
 	text := "雲の上は\nいつも晴れ"
 
@@ -254,6 +689,61 @@ func (s *S) TestText() {
 	})
 }
 
+func (s *S) TestElementEvalAsync() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+
+	s.Equal("click me", el.MustEval(`async () => {
+		await new Promise(r => setTimeout(r, 10))
+		return this.innerText
+	}`).String())
+	s.Equal("click me!", el.MustEval(`async function(s) { return this.innerText + s }`, "!").String())
+}
+
+func (s *S) TestElementEvalTimeout() {
+	p := s.browser.MustPage(srcFile("fixtures/click.html"))
+	defer p.MustClose()
+	el := p.MustElement("button")
+
+	err := el.Timeout(300 * time.Millisecond).Wait(`() => { while (true) {} }`)
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	// the endless loop should be terminated, the page should still be usable
+	s.Equal("click me", el.Timeout(5*time.Second).MustText())
+
+	// a pending promise doesn't block the page, the scripts of the page shouldn't be terminated
+	_, err = el.Timeout(300 * time.Millisecond).EvalWithOptions(rod.NewEvalOptions(`() => {
+		setTimeout(() => { for (let i = 0; i < 1e6; i++); window.pageScriptDone = true }, 100)
+		return new Promise(() => {})
+	}`, nil).WithTerminate())
+	s.ErrorIs(err, context.DeadlineExceeded)
+	p.Timeout(5 * time.Second).MustWait(`() => window.pageScriptDone`)
+
+	// without the option the timed out eval won't terminate the js of the page
+	_, err = el.Timeout(300 * time.Millisecond).Eval(`() => {
+		setTimeout(() => { for (let i = 0; i < 1e9; i++); window.pageLoopDone = true }, 200)
+		return new Promise(() => {})
+	}`)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	p.Timeout(time.Minute).MustWait(`() => window.pageLoopDone`)
+}
+
+func (s *S) TestOwnText() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`() => document.body.insertAdjacentHTML('beforeend',
+		'<label id="inbox">Inbox <span class="badge">3</span>mails</label>')`)
+	el := p.MustElement("#inbox")
+
+	s.Equal("Inbox mails", el.MustOwnText())
+	s.Equal("Inbox 3mails", el.MustText())
+	s.Equal("3", el.MustElement(".badge").MustOwnText())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustOwnText()
+	})
+}
+
 func (s *S) TestCheckbox() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("[type=checkbox]")
@@ -273,6 +763,11 @@ func (s *S) TestSelectText() {
 
 	s.Equal("t__t", el.MustText())
 
+	p.MustEval(`document.body.insertAdjacentHTML('beforeend', '<div contenteditable>ab<b>cd</b>ef</div>')`)
+	editable := p.MustElement("[contenteditable]")
+	editable.MustSelectAllText().MustInput("x")
+	s.Equal("x", editable.MustText())
+
 	s.Panics(func() {
 		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
 		el.MustSelectText("")
@@ -299,6 +794,29 @@ func (s *S) TestBlur() {
 	s.Equal("ok", *el.MustAttribute("a"))
 }
 
+func (s *S) TestSetValue() {
+	p := s.page.MustNavigate(srcFile("fixtures/input-value.html"))
+
+	date := p.MustElement("[type=date]")
+	s.Equal("2006-01-02", date.MustSetValue("2006-01-02").MustText())
+	s.Equal("2020-03-04", date.MustSetValue("Mar 4, 2020").MustText())
+	s.Equal("change", *date.MustAttribute("event"))
+
+	s.Panics(func() {
+		date.MustSetValue("not a date")
+	})
+
+	rng := p.MustElement("[type=range]")
+	s.Equal("4", rng.MustSetValue("3.8").MustText())
+	s.Equal("10", rng.MustSetValue("99").MustText())
+	s.Equal("0", rng.MustSetValue("-1").MustText())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		rng.MustSetValue("1")
+	})
+}
+
 func (s *S) TestSelectOptions() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("select")
@@ -319,6 +837,19 @@ func (s *S) TestMatches() {
 	})
 }
 
+func (s *S) TestElementMatchesAny() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement("textarea")
+	s.Equal(`[cols="30"]`, el.MustMatchesAny("input", `[cols="30"]`, "textarea"))
+	s.Equal("", el.MustMatchesAny("input", "button"))
+	s.Equal("", el.MustMatchesAny())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustMatchesAny("")
+	})
+}
+
 func (s *S) TestAttribute() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("textarea")
@@ -340,6 +871,34 @@ func (s *S) TestAttribute() {
 	})
 }
 
+func (s *S) TestAttributeNS() {
+	p := s.page.MustNavigate(srcFile("fixtures/svg-xlink.html"))
+	el := p.MustElement("use")
+
+	s.Equal("#dot", *el.MustAttributeNS("http://www.w3.org/1999/xlink", "href"))
+	s.Nil(el.MustAttributeNS("", "href"))
+	s.Nil(el.MustAttributeNS("http://www.w3.org/1999/xlink", "title"))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustAttributeNS("", "")
+	})
+}
+
+func (s *S) TestDescribeAttributes() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement("textarea")
+
+	attrs := el.MustDescribeAttributes()
+	s.Equal("30", attrs["cols"])
+	s.Equal("10", attrs["rows"])
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMDescribeNode{})
+		el.MustDescribeAttributes()
+	})
+}
+
 func (s *S) TestProperty() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("textarea")
@@ -375,6 +934,44 @@ func (s *S) TestSetFiles() {
 	s.Equal("alert.html", list[1].String())
 }
 
+func (s *S) TestSetFilesFromReaders() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement(`[type=file]`)
+
+	wait := el.MustWaitFileRead(2)
+	el.MustSetFilesFromReaders(
+		rod.NamedReader{Name: "a.txt", Reader: strings.NewReader("hello")},
+		rod.NamedReader{Name: "b.bin", Reader: bytes.NewReader([]byte{0, 0xff, 0x10})},
+	)
+	wait()
+
+	s.Equal(`["a.txt","b.bin"]`, el.MustEval(`Array.from(this.files).map(f => f.name)`).Raw)
+	s.Equal("hello", el.MustEval(`this.files[0].text()`).String())
+	s.Equal("[0,255,16]", el.MustEval(
+		`this.files[1].arrayBuffer().then(b => Array.from(new Uint8Array(b)))`,
+	).Raw)
+
+	s.Error(el.SetFilesFromReaders([]rod.NamedReader{{Name: "c.txt", Reader: iotest.TimeoutReader(strings.NewReader("c"))}}))
+}
+
+func (s *S) TestWaitFileRead() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+	el := p.MustElement(`[type=file]`)
+
+	wait := el.MustWaitFileRead(2)
+	el.MustSetFiles(
+		slash("fixtures/click.html"),
+		slash("fixtures/alert.html"),
+	)
+	wait()
+	s.EqualValues(2, el.MustEval(`this.files.length`).Int())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustWaitFileRead(1)()
+	})
+}
+
 func (s *S) TestSelectQuery() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("select")
@@ -399,6 +996,69 @@ func (s *S) TestEnter() {
 	s.True(p.MustHas("[event=submit]"))
 }
 
+func (s *S) TestElementVisibleOpacity() {
+	p := s.page.MustNavigate(srcFile("fixtures/visible.html"))
+
+	s.True(p.MustElement("#shown").MustVisible())
+	s.False(p.MustElement("#in-hidden").MustVisible())
+
+	// the opacity doesn't affect the visibility, such as a transparent native input styled by a wrapper
+	s.True(p.MustElement("#in-fade").MustVisible())
+
+	s.True(p.MustElement("#shown").MustPerceivable())
+	s.False(p.MustElement("#fade").MustPerceivable())
+	s.False(p.MustElement("#in-fade").MustPerceivable())
+	s.False(p.MustElement("#in-hidden").MustPerceivable())
+
+	el := p.MustElement("#in-fade")
+	go func() {
+		utils.Sleep(0.03)
+		p.MustElement("#fade").MustEval(`this.style.opacity = 1`)
+	}()
+	el.Timeout(3 * time.Second).MustWaitPerceivable()
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustPerceivable()
+	})
+}
+
+func (s *S) TestElementIntersectionRatio() {
+	p := s.page.MustNavigate(srcFile("fixtures/intersection.html"))
+
+	el := p.MustElement("#full")
+	s.Equal(1.0, el.MustIntersectionRatio())
+	s.InDelta(0.5, p.MustElement("#half").MustIntersectionRatio(), 0.01)
+	s.Equal(0.0, p.MustElement("#out").MustIntersectionRatio())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustIntersectionRatio()
+	})
+}
+
+func (s *S) TestWaitVisibleReason() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`() => document.body.insertAdjacentHTML('beforeend', '<div id="parent"><p id="child">x</p></div>')`)
+	parent := p.MustElement("#parent")
+	child := p.MustElement("#child")
+
+	check := func(el *rod.Element, style, reason string) {
+		el.MustEval(`s => this.style = s`, style)
+		err := el.Timeout(300 * time.Millisecond).WaitVisible()
+		s.ErrorIs(err, context.DeadlineExceeded)
+		s.Contains(err.Error(), "invisible because of "+reason)
+		el.MustEval(`() => this.style = ''`)
+	}
+
+	check(child, "display: none", "display: none")
+	check(parent, "display: none", "display: none of an ancestor")
+	check(child, "visibility: hidden", "visibility: hidden")
+	check(child, "display: block; width: 0; height: 0; overflow: hidden; position: fixed; top: 0", "zero size")
+
+	child.MustWaitVisible()
+}
+
 func (s *S) TestWaitInvisible() {
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
 	h4 := p.MustElement("h4")
@@ -446,6 +1106,74 @@ func (s *S) TestWaitStable() {
 		s.mc.stubErr(2, proto.DOMGetContentQuads{})
 		el.MustWaitStable()
 	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+		el.MustWaitStable()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(3, proto.RuntimeCallFunctionOn{})
+		el.MustWaitStable()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(4, proto.RuntimeCallFunctionOn{})
+		el.MustWaitStable()
+	})
+}
+
+func (s *S) TestWaitStableDetached() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+
+	// keep moving so that it will never be stable
+	p.MustEval(`() => {
+		const btn = document.querySelector('button')
+		setInterval(() => btn.style.marginLeft = (parseInt(btn.style.marginLeft || 0) + 1) + 'px', 30)
+	}`)
+
+	go func() {
+		utils.Sleep(0.3)
+		p.MustEval(`() => document.querySelector('button').remove()`)
+	}()
+
+	start := time.Now()
+	err := el.Timeout(10 * time.Second).WaitStable(time.Millisecond * 100)
+	s.ErrorIs(err, rod.ErrElementDetached)
+	s.Less(time.Since(start), 5*time.Second)
+}
+
+func (s *S) TestWaitStableThreshold() {
+	p := s.page.MustNavigate(srcFile("fixtures/wait-stable-jitter.html"))
+	el := p.MustElement("button")
+	el.MustWaitStableThreshold(1)
+
+	p.MustNavigate(srcFile("fixtures/wait-stable.html"))
+	el = p.MustElement("button")
+	el.MustWaitStableThreshold(1)
+	s.Greater(el.MustShape()[0].X(), 400.0)
+
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.DOMGetContentQuads{})
+		el.MustWaitStableThreshold(1)
+	})
+}
+
+func (s *S) TestWaitStableOpacity() {
+	p := s.page.MustNavigate(srcFile("fixtures/wait-stable-opacity.html"))
+	el := p.MustElement("button")
+	el.MustWaitStable()
+	s.Equal("1", el.MustEval(`getComputedStyle(this).opacity`).String())
+}
+
+func (s *S) TestWaitStableShape() {
+	p := s.page.MustNavigate(srcFile("fixtures/wait-stable.html"))
+	el := p.MustElement("button")
+	shape := el.MustWaitStableShape()
+	s.Equal(el.MustShape(), shape)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustWaitStableShape()
+	})
 }
 
 func (s *S) TestCanvasToImage() {
@@ -468,6 +1196,12 @@ func (s *S) TestResource() {
 	})
 	s.Equal([]byte("ok"), el.MustResource())
 
+	p.MustWaitLoad()
+	s.Equal(15456, len(p.MustElement(".bg").MustResource()))
+	s.Panics(func() {
+		p.MustElement("p").MustResource()
+	})
+
 	s.Panics(func() {
 		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
 		el.MustResource()
@@ -490,6 +1224,13 @@ func (s *S) TestElementScreenshot() {
 	s.EqualValues(30, img.Bounds().Dy())
 	s.FileExists(f)
 
+	// the quality should be passed to the jpeg encoder
+	high, err := el.Screenshot(proto.PageCaptureScreenshotFormatJpeg, 100)
+	utils.E(err)
+	low, err := el.Screenshot(proto.PageCaptureScreenshotFormatJpeg, 10)
+	utils.E(err)
+	s.Less(len(low), len(high))
+
 	s.Panics(func() {
 		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
 		el.MustScreenshot()
@@ -504,6 +1245,86 @@ func (s *S) TestElementScreenshot() {
 	})
 }
 
+func (s *S) TestElementScreenshotTall() {
+	p := s.browser.MustPage(srcFile("fixtures/tall-element.html")).MustWaitLoad()
+	defer p.MustClose()
+
+	height := p.MustEval(`innerHeight`).Int()
+	s.Less(height, 2000)
+
+	el := p.MustElement("div")
+	img, err := png.Decode(bytes.NewBuffer(el.MustScreenshot()))
+	utils.E(err)
+	s.EqualValues(300, img.Bounds().Dx())
+	s.EqualValues(2000, img.Bounds().Dy())
+	s.Equal(height, p.MustEval(`innerHeight`).Int()) // the viewport should be restored
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageGetLayoutMetrics{})
+		el.MustScreenshot()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.EmulationSetDeviceMetricsOverride{})
+		el.MustScreenshot()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.DOMGetBoxModel{})
+		el.MustScreenshot()
+	})
+}
+
+func (s *S) TestElementScreenshotRegion() {
+	f := filepath.Join("tmp", "screenshots", utils.RandString(8)+".png")
+	p := s.browser.MustPage(srcFile("fixtures/tall-element.html")).MustWaitLoad()
+	defer p.MustClose()
+
+	el := p.MustElement("div")
+	img, err := png.Decode(bytes.NewBuffer(el.MustScreenshotRegion(100, 10, 50, 20, f)))
+	utils.E(err)
+	s.EqualValues(50, img.Bounds().Dx())
+	s.EqualValues(20, img.Bounds().Dy())
+	s.FileExists(f)
+
+	// the top of the gradient is red
+	r, _, b, _ := img.At(25, 10).RGBA()
+	s.Greater(r, b)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMGetBoxModel{})
+		el.MustScreenshotRegion(0, 0, 10, 10)
+	})
+}
+
+func (s *S) TestElementScreenshotWhenLoaded() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	mux.HandleFunc("/", httpHTML(`<html><div><img src="/icon"><img src="/broken"></div></html>`))
+	mux.HandleFunc("/icon", func(w http.ResponseWriter, r *http.Request) {
+		utils.Sleep(0.3)
+		http.ServeFile(w, r, slash("fixtures/icon.png"))
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	p := s.browser.MustPage(url)
+	defer p.MustClose()
+
+	el := p.MustElement("div")
+	el.MustScreenshotWhenLoaded()
+	s.True(p.MustEval(`document.querySelector('img').naturalWidth > 0`).Bool())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustScreenshotWhenLoaded()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+		el.MustScreenshotWhenLoaded()
+	})
+}
+
 func (s *S) TestUseReleasedElement() {
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
 	btn := p.MustElement("button")
@@ -545,6 +1366,11 @@ func (s *S) TestFnErr() {
 	s.Contains(err.Error(), "ReferenceError: foo is not defined")
 	s.True(errors.Is(err, rod.ErrEval))
 	s.Equal(proto.RuntimeRemoteObjectSubtypeError, rod.AsError(err).Details.(*proto.RuntimeRemoteObject).Subtype)
+	s.Regexp(`at line \d+, column \d+`, err.Error())
+
+	_, err = el.Eval(`() => { throw 'custom' }`)
+	s.Contains(err.Error(), "Uncaught")
+	s.Contains(err.Error(), "custom")
 
 	_, err = el.ElementByJS(rod.NewEvalOptions("foo()", nil))
 	s.Error(err)
@@ -565,6 +1391,64 @@ func (s *S) TestElementEWithDepth() {
 	s.Contains(string(data), checkStr)
 }
 
+func (s *S) TestElementAX() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+	s.Equal("button", el.MustAXRole())
+	s.Equal("click me", el.MustAXName())
+
+	s.mc.stub(1, proto.AccessibilityGetPartialAXTree{}, func(send func() ([]byte, error)) ([]byte, error) {
+		return utils.MustToJSONBytes(proto.AccessibilityGetPartialAXTreeResult{
+			Nodes: []*proto.AccessibilityAXNode{{}},
+		}), nil
+	})
+	_, err := el.AXNode()
+	s.True(errors.Is(err, rod.ErrAXNodeNotFound))
+
+	id := el.MustDescribe().BackendNodeID
+
+	s.mc.stub(1, proto.AccessibilityGetPartialAXTree{}, func(send func() ([]byte, error)) ([]byte, error) {
+		return utils.MustToJSONBytes(proto.AccessibilityGetPartialAXTreeResult{
+			Nodes: []*proto.AccessibilityAXNode{{BackendDOMNodeID: id}},
+		}), nil
+	})
+	s.Equal("", el.MustAXRole())
+
+	s.mc.stub(1, proto.AccessibilityGetPartialAXTree{}, func(send func() ([]byte, error)) ([]byte, error) {
+		return utils.MustToJSONBytes(proto.AccessibilityGetPartialAXTreeResult{
+			Nodes: []*proto.AccessibilityAXNode{{BackendDOMNodeID: id}},
+		}), nil
+	})
+	s.Equal("", el.MustAXName())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMDescribeNode{})
+		el.MustAXNode()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.AccessibilityGetPartialAXTree{})
+		el.MustAXRole()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.AccessibilityGetPartialAXTree{})
+		el.MustAXName()
+	})
+}
+
+func (s *S) TestElementFocusNoScroll() {
+	p := s.page.MustNavigate(srcFile("fixtures/scroll.html"))
+	btn := p.MustElement("button")
+
+	btn.MustFocusNoScroll()
+	s.True(btn.MustEval(`document.activeElement === this`).Bool())
+	s.EqualValues(0, p.MustEval(`window.scrollY`).Int())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		btn.MustFocusNoScroll()
+	})
+}
+
 func (s *S) TestElementOthers() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("form")
@@ -576,6 +1460,31 @@ func (s *S) TestElementOthers() {
 	el.MustWait(`true`)
 	s.Equal("form", el.MustElementByJS(`this`).MustDescribe().LocalName)
 	s.Len(el.MustElementsByJS(`[]`), 0)
+
+	list := p.MustElement("select").MustElementsByJS(`(i) => Array.from(this.children).slice(i)`, 2)
+	s.Len(list, 2)
+	s.Equal("C", list.First().MustText())
+	s.Equal("CC", list.Last().MustText())
+}
+
+func (s *S) TestElementWaitBox() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`document.body.insertAdjacentHTML('beforeend', '<div id="chart"></div>')`)
+	el := p.MustElement("#chart")
+
+	go func() {
+		utils.Sleep(0.03)
+		el.MustEval(`this.style = 'width: 30px; height: 20px'`)
+	}()
+
+	box := el.Timeout(3 * time.Second).MustWaitBox()
+	s.EqualValues(30, box.Width)
+	s.EqualValues(20, box.Height)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMGetBoxModel{})
+		el.MustWaitBox()
+	})
 }
 
 func (s *S) TestElementFromPointErr() {
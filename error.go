@@ -31,6 +31,33 @@ var (
 
 	// ErrNotInteractable error. Check the doc of Element.Interactable for details.
 	ErrNotInteractable = errors.New("element is not cursor interactable")
+
+	// ErrInvalidMouseButton error
+	ErrInvalidMouseButton = errors.New("invalid mouse button")
+
+	// ErrAXNodeNotFound error
+	ErrAXNodeNotFound = errors.New("cannot find the accessibility node")
+
+	// ErrNoShadowRoot error
+	ErrNoShadowRoot = errors.New("element has no shadow root")
+
+	// ErrPageCrashed error. The cdp calls of a crashed page will fail with it, use Page.Reload to recover the page.
+	ErrPageCrashed = errors.New("page crashed")
+
+	// ErrRequestFailed error. The details is the proto.NetworkLoadingFailed event.
+	ErrRequestFailed = errors.New("request failed")
+
+	// ErrInvalidModifier error. The details is the rune of the modifier.
+	ErrInvalidModifier = errors.New("invalid modifier key")
+
+	// ErrInvalidShortcut error. The details is the combo string.
+	ErrInvalidShortcut = errors.New("invalid keyboard shortcut")
+
+	// ErrElementDetached error. The details is the *rod.Element.
+	ErrElementDetached = errors.New("element is detached")
+
+	// ErrHARNotStarted error
+	ErrHARNotStarted = errors.New("har recording not started")
 )
 
 // Error type for rod
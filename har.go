@@ -0,0 +1,377 @@
+package rod
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// HAR is the root of the HTTP Archive 1.2 format, it can be marshaled into json directly
+// and loaded by the existing HAR analyzers. Spec: http://www.softwareishard.com/blog/har-12-spec
+type HAR struct {
+	Log *HARLog `json:"log"`
+}
+
+// HARLog of HAR
+type HARLog struct {
+	Version string      `json:"version"`
+	Creator *HARCreator `json:"creator"`
+	Entries []*HAREntry `json:"entries"`
+}
+
+// HARCreator of HAR
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry of HAR, one for each request
+type HAREntry struct {
+	StartedDateTime time.Time    `json:"startedDateTime"`
+	Time            float64      `json:"time"` // ms
+	Request         *HARRequest  `json:"request"`
+	Response        *HARResponse `json:"response"`
+	Cache           struct{}     `json:"cache"`
+	Timings         *HARTimings  `json:"timings"`
+	ServerIPAddress string       `json:"serverIPAddress,omitempty"`
+	Connection      string       `json:"connection,omitempty"`
+}
+
+// HARRequest of HAR
+type HARRequest struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*HARNameValue `json:"cookies"`
+	Headers     []*HARNameValue `json:"headers"`
+	QueryString []*HARNameValue `json:"queryString"`
+	PostData    *HARPostData    `json:"postData,omitempty"`
+	HeadersSize int             `json:"headersSize"`
+	BodySize    int             `json:"bodySize"`
+}
+
+// HARResponse of HAR. If the request failed the Status will be 0 and the Comment will be the error text.
+type HARResponse struct {
+	Status      int             `json:"status"`
+	StatusText  string          `json:"statusText"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*HARNameValue `json:"cookies"`
+	Headers     []*HARNameValue `json:"headers"`
+	Content     *HARContent     `json:"content"`
+	RedirectURL string          `json:"redirectURL"`
+	HeadersSize int             `json:"headersSize"`
+	BodySize    int             `json:"bodySize"`
+	Comment     string          `json:"comment,omitempty"`
+}
+
+// HARNameValue of HAR, used by headers, query strings, etc
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData of HAR
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent of HAR. The body text is not recorded, use Page.WaitRequest to get the body of a request.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+// HARTimings of HAR, in ms, -1 means the phase doesn't apply to the request
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+type harRecorder struct {
+	lock    sync.Mutex
+	stop    func()
+	done    chan struct{}
+	entries []*HAREntry
+}
+
+type harPending struct {
+	entry     *HAREntry
+	start     time.Duration // monotonic time when the request was sent
+	response  time.Duration // monotonic time when the response headers received
+	timing    *proto.NetworkResourceTiming
+	headerLen float64
+}
+
+// StartHAR starts to record the network activities of the page, use Page.StopHAR to stop and get the
+// result in HAR 1.2 format. Calling it again will discard the previous recording.
+func (p *Page) StartHAR() error {
+	_, _ = p.StopHAR()
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	done := make(chan struct{})
+	pending := map[proto.NetworkRequestID]*harPending{}
+	rec := p.har
+
+	// the callbacks are called one by one, only the fields read by StopHAR need the lock
+	update := func(id proto.NetworkRequestID, fn func(r *harPending)) {
+		if r, has := pending[id]; has {
+			rec.lock.Lock()
+			defer rec.lock.Unlock()
+			fn(r)
+		}
+	}
+
+	finish := func(id proto.NetworkRequestID, t *proto.MonotonicTime) {
+		update(id, func(r *harPending) {
+			r.entry.Timings = harTimings(r, t.Duration)
+			r.entry.Time = harTotal(r.entry.Timings)
+		})
+		delete(pending, id)
+	}
+
+	bodySize := func(r *harPending, encoded float64) {
+		r.entry.Response.BodySize = int(encoded - r.headerLen)
+	}
+
+	wait, err := p.browser.eachEventE(ctx, p.SessionID, func(e *proto.NetworkRequestWillBeSent) {
+		if e.RedirectResponse != nil {
+			update(e.RequestID, func(r *harPending) {
+				r.setResponse(e.RedirectResponse, e.Timestamp.Duration)
+				r.entry.Response.RedirectURL = e.Request.URL
+				bodySize(r, e.RedirectResponse.EncodedDataLength)
+			})
+			finish(e.RequestID, e.Timestamp)
+		}
+
+		r := &harPending{entry: newHAREntry(e), start: e.Timestamp.Duration}
+		pending[e.RequestID] = r
+
+		rec.lock.Lock()
+		rec.entries = append(rec.entries, r.entry)
+		rec.lock.Unlock()
+	}, func(e *proto.NetworkResponseReceived) {
+		update(e.RequestID, func(r *harPending) {
+			r.setResponse(e.Response, e.Timestamp.Duration)
+		})
+	}, func(e *proto.NetworkDataReceived) {
+		update(e.RequestID, func(r *harPending) {
+			r.entry.Response.Content.Size += int(e.DataLength)
+		})
+	}, func(e *proto.NetworkLoadingFinished) {
+		update(e.RequestID, func(r *harPending) {
+			bodySize(r, e.EncodedDataLength)
+		})
+		finish(e.RequestID, e.Timestamp)
+	}, func(e *proto.NetworkLoadingFailed) {
+		update(e.RequestID, func(r *harPending) {
+			r.entry.Response.Comment = e.ErrorText
+		})
+		finish(e.RequestID, e.Timestamp)
+	})
+
+	if err != nil {
+		// the wait returns immediately after the cancel, it restores the domain and unsubscribes
+		cancel()
+		wait()
+		return err
+	}
+
+	rec.lock.Lock()
+	rec.stop = cancel
+	rec.done = done
+	rec.entries = []*HAREntry{}
+	rec.lock.Unlock()
+
+	go func() {
+		defer close(done)
+		wait()
+	}()
+
+	return nil
+}
+
+// StopHAR stops the recording started by Page.StartHAR and returns the result. The requests that haven't
+// finished yet are also included, their timings will be incomplete.
+func (p *Page) StopHAR() (*HAR, error) {
+	p.har.lock.Lock()
+	stop, done := p.har.stop, p.har.done
+	p.har.stop = nil
+	p.har.lock.Unlock()
+
+	if stop == nil {
+		return nil, newErr(ErrHARNotStarted, nil, "call Page.StartHAR first")
+	}
+
+	stop()
+	<-done
+
+	p.har.lock.Lock()
+	defer p.har.lock.Unlock()
+
+	for _, e := range p.har.entries {
+		if e.Timings == nil {
+			e.Timings = &HARTimings{-1, -1, -1, 0, 0, 0, -1}
+		}
+	}
+
+	return &HAR{Log: &HARLog{
+		Version: "1.2",
+		Creator: &HARCreator{Name: "rod"},
+		Entries: p.har.entries,
+	}}, nil
+}
+
+func newHAREntry(e *proto.NetworkRequestWillBeSent) *HAREntry {
+	req := &HARRequest{
+		Method:      e.Request.Method,
+		URL:         e.Request.URL + e.Request.URLFragment,
+		Cookies:     []*HARNameValue{},
+		Headers:     harHeaders(e.Request.Headers),
+		QueryString: []*HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    len(e.Request.PostData),
+	}
+
+	if u, err := url.Parse(e.Request.URL); err == nil {
+		for k, list := range u.Query() {
+			for _, v := range list {
+				req.QueryString = append(req.QueryString, &HARNameValue{k, v})
+			}
+		}
+		sortHARNameValues(req.QueryString)
+	}
+
+	if e.Request.HasPostData {
+		req.PostData = &HARPostData{
+			MimeType: e.Request.Headers["Content-Type"].String(),
+			Text:     e.Request.PostData,
+		}
+	}
+
+	return &HAREntry{
+		StartedDateTime: e.WallTime.Time,
+		Request:         req,
+		Response: &HARResponse{
+			Cookies:     []*HARNameValue{},
+			Headers:     []*HARNameValue{},
+			Content:     &HARContent{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+	}
+}
+
+func (r *harPending) setResponse(res *proto.NetworkResponse, t time.Duration) {
+	r.response = t
+	r.timing = res.Timing
+	r.headerLen = res.EncodedDataLength
+
+	e := r.entry
+	e.Request.HTTPVersion = res.Protocol
+	if res.RequestHeaders != nil {
+		e.Request.Headers = harHeaders(res.RequestHeaders)
+	}
+	if res.RequestHeadersText != "" {
+		e.Request.HeadersSize = len(res.RequestHeadersText)
+	}
+
+	e.Response.Status = int(res.Status)
+	e.Response.StatusText = res.StatusText
+	e.Response.HTTPVersion = res.Protocol
+	e.Response.Headers = harHeaders(res.Headers)
+	e.Response.Content.MimeType = res.MIMEType
+	if res.HeadersText != "" {
+		e.Response.HeadersSize = len(res.HeadersText)
+	}
+
+	e.ServerIPAddress = strings.Trim(res.RemoteIPAddress, "[]")
+	if res.ConnectionID != 0 {
+		e.Connection = strconv.FormatInt(int64(res.ConnectionID), 10)
+	}
+}
+
+// harTimings converts the cdp timing to HAR timings, the same way as the devtools does
+func harTimings(r *harPending, end time.Duration) *HARTimings {
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+
+	t := r.timing
+	if t == nil {
+		// such as the cached requests, the cdp has no timing details for them
+		if r.response == 0 {
+			r.response = end
+		}
+		return &HARTimings{
+			Blocked: -1, DNS: -1, Connect: -1, SSL: -1,
+			Wait:    ms(r.response - r.start),
+			Receive: ms(end - r.response),
+		}
+	}
+
+	phase := func(start, end float64) float64 {
+		if start < 0 {
+			return -1
+		}
+		return end - start
+	}
+
+	blocked := t.SendStart
+	for _, start := range []float64{t.DNSStart, t.ConnectStart} {
+		if start >= 0 {
+			blocked = start
+			break
+		}
+	}
+
+	requestTime := time.Duration(t.RequestTime * float64(time.Second))
+
+	return &HARTimings{
+		Blocked: blocked,
+		DNS:     phase(t.DNSStart, t.DNSEnd),
+		Connect: phase(t.ConnectStart, t.ConnectEnd),
+		SSL:     phase(t.SslStart, t.SslEnd),
+		Send:    t.SendEnd - t.SendStart,
+		Wait:    t.ReceiveHeadersEnd - t.SendEnd,
+		Receive: ms(end-requestTime) - t.ReceiveHeadersEnd,
+	}
+}
+
+// harTotal sums the phases, the ssl is already included in the connect
+func harTotal(t *HARTimings) float64 {
+	total := 0.0
+	for _, v := range []float64{t.Blocked, t.DNS, t.Connect, t.Send, t.Wait, t.Receive} {
+		if v > 0 {
+			total += v
+		}
+	}
+	return total
+}
+
+func harHeaders(headers proto.NetworkHeaders) []*HARNameValue {
+	list := []*HARNameValue{}
+	for k, v := range headers {
+		list = append(list, &HARNameValue{k, v.String()})
+	}
+	sortHARNameValues(list)
+	return list
+}
+
+func sortHARNameValues(list []*HARNameValue) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name == list[j].Name {
+			return list[i].Value < list[j].Value
+		}
+		return list[i].Name < list[j].Name
+	})
+}
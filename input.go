@@ -1,10 +1,16 @@
 package rod
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
 
+	"github.com/go-rod/rod/lib/assets/js"
 	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/input/layout"
 	"github.com/go-rod/rod/lib/proto"
 )
 
@@ -16,6 +22,10 @@ type Keyboard struct {
 
 	// modifiers are currently beening pressed
 	modifiers int64
+
+	layout layout.Layout
+
+	inputEvent bool // check Keyboard.SetInputEvent
 }
 
 func (k *Keyboard) getModifiers() int64 {
@@ -25,12 +35,48 @@ func (k *Keyboard) getModifiers() int64 {
 	return k.modifiers
 }
 
-// Down holds the key down
+// SetLayout sets the keyboard layout used to decide which physical keys and modifiers to press,
+// such as layout.German. If l is nil, the default US layout will be used.
+func (k *Keyboard) SetLayout(l layout.Layout) {
+	k.Lock()
+	defer k.Unlock()
+
+	k.layout = l
+}
+
+// SetInputEvent enables dispatching the "input" and "change" events on the focused element after Keyboard.InsertText,
+// some controlled components of the frameworks ignore the text inserted without them.
+func (k *Keyboard) SetInputEvent(enable bool) {
+	k.Lock()
+	defer k.Unlock()
+
+	k.inputEvent = enable
+}
+
+func (k *Keyboard) encode(key rune) []*proto.InputDispatchKeyEvent {
+	if k.layout == nil {
+		return input.Encode(key)
+	}
+	return k.layout.Encode(key)
+}
+
+// the name of the key for tracing
+func keyName(key rune) string {
+	if k, has := input.Keys[key]; has {
+		return k.Key
+	}
+	return string(key)
+}
+
+// Down holds the key down. If the key is unknown, such as an emoji, it will be inserted as text.
 func (k *Keyboard) Down(key rune) error {
 	k.Lock()
 	defer k.Unlock()
 
-	actions := input.Encode(key)
+	actions := k.encode(key)
+	if actions == nil {
+		return k.insert(string(key), string(key))
+	}
 
 	err := actions[0].Call(k.page)
 	if err != nil {
@@ -40,12 +86,16 @@ func (k *Keyboard) Down(key rune) error {
 	return nil
 }
 
-// Up releases the key
+// Up releases the key. If the key is unknown, such as an emoji, nothing will happen,
+// because it's already inserted by the Keyboard.Down.
 func (k *Keyboard) Up(key rune) error {
 	k.Lock()
 	defer k.Unlock()
 
-	actions := input.Encode(key)
+	actions := k.encode(key)
+	if actions == nil {
+		return nil
+	}
 
 	err := actions[len(actions)-1].Call(k.page)
 	if err != nil {
@@ -55,38 +105,238 @@ func (k *Keyboard) Up(key rune) error {
 	return nil
 }
 
-// Press a key. It's a combination of Keyboard.Down and Keyboard.Up
+// Press a key. It's a combination of Keyboard.Down and Keyboard.Up.
+// If the key is unknown, such as an emoji, it will be inserted as text.
 func (k *Keyboard) Press(key rune) error {
 	k.Lock()
 	defer k.Unlock()
 
-	if k.page.browser.trace {
-		defer k.page.Overlay(0, 0, 200, 0, "press "+input.Keys[key].Key)()
+	actions := k.encode(key)
+	if actions == nil {
+		return k.insert(string(key), string(key))
 	}
+
+	defer k.page.tryTraceInput("press " + actions[0].Key)()
 	k.page.browser.trySlowmotion()
 
-	actions := input.Encode(key)
+	k.modifiers = actions[0].Modifiers
+	defer func() { k.modifiers = 0 }()
+
+	for _, action := range actions {
+		err := action.Call(k.page)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PressRepeat holds the key down and repeats the keydown count times with the interval, then releases the key.
+// If the key is unknown, such as an emoji, it will be inserted as text for count+1 times.
+func (k *Keyboard) PressRepeat(key rune, count int, interval time.Duration) error {
+	k.Lock()
+	defer k.Unlock()
+
+	actions := k.encode(key)
+	if actions == nil {
+		text := strings.Repeat(string(key), count+1)
+		return k.insert(text, text)
+	}
+
+	defer k.page.tryTraceInput(fmt.Sprintf("press %s repeat %d", actions[0].Key, count))()
+	k.page.browser.trySlowmotion()
 
 	k.modifiers = actions[0].Modifiers
 	defer func() { k.modifiers = 0 }()
 
+	down, up := actions[:len(actions)-1], actions[len(actions)-1]
+
+	for i := 0; i <= count; i++ {
+		if i > 0 {
+			t := time.NewTimer(interval)
+			select {
+			case <-t.C:
+			case <-k.page.ctx.Done():
+				t.Stop()
+				return k.page.ctx.Err()
+			}
+		}
+
+		for _, action := range down {
+			action.AutoRepeat = i > 0
+			err := action.Call(k.page)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return up.Call(k.page)
+}
+
+// PressWithModifiers holds the modifiers down in order, such as input.Shift, presses the key,
+// then releases the modifiers in reverse order. Such as to press Shift+Tab.
+func (k *Keyboard) PressWithModifiers(key rune, modifiers ...rune) (err error) {
+	k.Lock()
+	defer k.Unlock()
+
+	for _, m := range modifiers {
+		if input.Modifiers[m] == 0 || k.encode(m) == nil {
+			return newErr(ErrInvalidModifier, m, fmt.Sprintf("%q", m))
+		}
+	}
+
+	defer k.page.tryTraceInput("press " + keyName(key) + " with modifiers")()
+	k.page.browser.trySlowmotion()
+
+	// release the pressed modifiers even if the press fails, or the browser will keep holding them
+	pressed := []rune{}
+	defer func() {
+		for i := len(pressed) - 1; i >= 0; i-- {
+			m := pressed[i]
+			k.modifiers &^= input.Modifiers[m]
+			actions := k.encode(m)
+			up := actions[len(actions)-1]
+			up.Modifiers |= k.modifiers
+			e := up.Call(k.page)
+			if err == nil {
+				err = e
+			}
+		}
+		k.modifiers = 0
+	}()
+
+	for _, m := range modifiers {
+		down := k.encode(m)[0]
+		down.Modifiers |= k.modifiers | input.Modifiers[m]
+		err := down.Call(k.page)
+		if err != nil {
+			return err
+		}
+		k.modifiers |= input.Modifiers[m]
+		pressed = append(pressed, m)
+	}
+
+	actions := k.encode(key)
+	if actions == nil {
+		return k.insert(string(key), string(key))
+	}
 	for _, action := range actions {
+		action.Modifiers |= k.modifiers
 		err := action.Call(k.page)
 		if err != nil {
 			return err
 		}
 	}
+
 	return nil
 }
 
-// InsertText is like pasting text into the page
+// Shortcut presses the keyboard shortcut described by the combo, such as "Ctrl+Shift+K" or "Meta+Enter".
+// The modifiers are Ctrl, Shift, Alt, and Meta, the key names are case-insensitive.
+func (k *Keyboard) Shortcut(combo string) error {
+	key, modifiers, err := parseShortcut(combo)
+	if err != nil {
+		return err
+	}
+	return k.PressWithModifiers(key, modifiers...)
+}
+
+var shortcutModifiers = map[string]rune{
+	"ctrl":    input.Control,
+	"control": input.Control,
+	"shift":   input.Shift,
+	"alt":     input.Alt,
+	"option":  input.Alt,
+	"meta":    input.Meta,
+	"cmd":     input.Meta,
+	"command": input.Meta,
+}
+
+func parseShortcut(combo string) (key rune, modifiers []rune, err error) {
+	parts := strings.Split(combo, "+")
+	if combo == "+" || strings.HasSuffix(combo, "++") { // such as "Ctrl++"
+		parts = append(parts[:len(parts)-2], "+")
+	}
+
+	invalid := func(msg string) error {
+		return newErr(ErrInvalidShortcut, combo, fmt.Sprintf(`"%s", %s`, combo, msg))
+	}
+
+	shift := false
+	for _, name := range parts[:len(parts)-1] {
+		m, has := shortcutModifiers[strings.ToLower(strings.TrimSpace(name))]
+		if !has {
+			return 0, nil, invalid(fmt.Sprintf(`unknown modifier "%s"`, name))
+		}
+		modifiers = append(modifiers, m)
+		shift = shift || m == input.Shift
+	}
+
+	name := parts[len(parts)-1]
+	if name != " " {
+		name = strings.TrimSpace(name)
+	}
+
+	if list := []rune(name); len(list) == 1 {
+		key = list[0]
+		if shift {
+			return unicode.ToUpper(key), modifiers, nil
+		}
+		return unicode.ToLower(key), modifiers, nil
+	}
+
+	if strings.EqualFold(name, "Space") {
+		return ' ', modifiers, nil
+	}
+
+	// the same name may be shared by several runes, such as "\r" and "\n", use the smallest for stable result
+	found := false
+	for r, k := range input.Keys {
+		if strings.EqualFold(k.Key, name) && (!found || r < key) {
+			key = r
+			found = true
+		}
+	}
+	if !found {
+		return 0, nil, invalid(fmt.Sprintf(`unknown key "%s"`, name))
+	}
+	return key, modifiers, nil
+}
+
+// InsertText is like pasting text into the page.
+// Check Keyboard.SetInputEvent to dispatch the "input" event after the insertion.
 func (k *Keyboard) InsertText(text string) error {
+	err := k.insertText(text, text)
+	if err != nil {
+		return err
+	}
+
 	k.Lock()
-	defer k.Unlock()
+	fire := k.inputEvent
+	k.Unlock()
+
+	if !fire {
+		return nil
+	}
 
-	if k.page.browser.trace {
-		defer k.page.Overlay(0, 0, 200, 0, "insert text "+text)()
+	el, err := k.page.Sleeper(nil).ElementByJS(NewEvalOptions(`document.activeElement`, nil))
+	if err != nil {
+		return err
 	}
+	_, err = el.EvalWithOptions(jsHelper(js.InputEvent, nil).ByUser())
+	return err
+}
+
+func (k *Keyboard) insertText(text, traceText string) error {
+	k.Lock()
+	defer k.Unlock()
+
+	return k.insert(text, traceText)
+}
+
+func (k *Keyboard) insert(text, traceText string) error {
+	defer k.page.tryTraceInput("insert text " + traceText)()
 	k.page.browser.trySlowmotion()
 
 	err := proto.InputInsertText{Text: text}.Call(k.page)
@@ -106,17 +356,31 @@ type Mouse struct {
 
 	// the buttons is currently beening pressed, reflects the press order
 	buttons []proto.InputMouseButton
+
+	trail bool // draw the path of the mouse, check Page.ShowMouseTrail
 }
 
-// Move to the absolute position with specified steps
+// DefaultMouseMoveSteps is the number of steps Mouse.Move uses when the steps is less than 1.
+// Some hover menus only react to a sequence of "mousemove" events, set it to a larger number for them.
+var DefaultMouseMoveSteps = 1
+
+// Move to the absolute position with specified steps.
+// If steps is less than 1, DefaultMouseMoveSteps will be used.
 func (m *Mouse) Move(x, y float64, steps int) error {
 	m.Lock()
 	defer m.Unlock()
 
+	if steps < 1 {
+		steps = DefaultMouseMoveSteps
+	}
 	if steps < 1 {
 		steps = 1
 	}
 
+	if m.page.browser.trace {
+		m.page.browser.traceLog(&TraceMsg{TraceTypeInput, fmt.Sprintf("move to (%.2f, %.2f)", x, y)})
+	}
+
 	stepX := (x - m.x) / float64(steps)
 	stepY := (y - m.y) / float64(steps)
 
@@ -144,6 +408,10 @@ func (m *Mouse) Move(x, y float64, steps int) error {
 		m.x = toX
 		m.y = toY
 
+		if m.trail {
+			m.updateMouseTrail()
+		}
+
 		if m.page.browser.trace {
 			if !m.updateMouseTracer() {
 				m.initMouseTracer()
@@ -160,9 +428,7 @@ func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	m.Lock()
 	defer m.Unlock()
 
-	if m.page.browser.trace {
-		defer m.page.Overlay(0, 0, 200, 0, fmt.Sprintf("scroll (%.2f, %.2f)", offsetX, offsetY))()
-	}
+	defer m.page.tryTraceInput(fmt.Sprintf("scroll (%.2f, %.2f) at (%.2f, %.2f)", offsetX, offsetY, m.x, m.y))()
 	m.page.browser.trySlowmotion()
 
 	if steps < 1 {
@@ -193,8 +459,58 @@ func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	return nil
 }
 
+// WheelMode is the unit of the deltas of a wheel event, it's the same as the WheelEvent.deltaMode
+type WheelMode int
+
+const (
+	// WheelModePixel scrolls by pixels, such as the trackpad
+	WheelModePixel WheelMode = iota
+
+	// WheelModeLine scrolls by lines, such as the mouse wheel
+	WheelModeLine
+
+	// WheelModePage scrolls by pages
+	WheelModePage
+)
+
+// Wheel dispatches one wheel event at the current position of the mouse with the deltas in the unit of mode.
+// Only the WheelModePixel is trusted, the other modes are dispatched via js and won't scroll the page natively.
+func (m *Mouse) Wheel(deltaX, deltaY float64, mode WheelMode) error {
+	if mode == WheelModePixel {
+		return m.Scroll(deltaX, deltaY, 1)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	defer m.page.tryTraceInput(fmt.Sprintf("wheel (%.2f, %.2f) mode %d at (%.2f, %.2f)", deltaX, deltaY, mode, m.x, m.y))()
+	m.page.browser.trySlowmotion()
+
+	_, err := m.page.EvalWithOptions(jsHelper(js.DispatchWheel, JSArgs{
+		m.x, m.y, deltaX, deltaY, int(mode), m.page.Keyboard.getModifiers(),
+	}))
+	return err
+}
+
+// the button should be one of none, left, middle, right, back, and forward
+func checkMouseButton(button proto.InputMouseButton) error {
+	if _, has := input.MouseKeys[button]; has || button == proto.InputMouseButtonNone {
+		return nil
+	}
+	return newErr(
+		ErrInvalidMouseButton,
+		button,
+		fmt.Sprintf(`"%s", it should be one of none, left, middle, right, back, and forward`, button),
+	)
+}
+
 // Down holds the button down
 func (m *Mouse) Down(button proto.InputMouseButton, clicks int64) error {
+	err := checkMouseButton(button)
+	if err != nil {
+		return err
+	}
+
 	m.Lock()
 	defer m.Unlock()
 
@@ -202,7 +518,7 @@ func (m *Mouse) Down(button proto.InputMouseButton, clicks int64) error {
 
 	_, buttons := input.EncodeMouseButton(toButtons)
 
-	err := proto.InputDispatchMouseEvent{
+	err = proto.InputDispatchMouseEvent{
 		Type:       proto.InputDispatchMouseEventTypeMousePressed,
 		Button:     button,
 		Buttons:    buttons,
@@ -220,6 +536,11 @@ func (m *Mouse) Down(button proto.InputMouseButton, clicks int64) error {
 
 // Up releases the button
 func (m *Mouse) Up(button proto.InputMouseButton, clicks int64) error {
+	err := checkMouseButton(button)
+	if err != nil {
+		return err
+	}
+
 	m.Lock()
 	defer m.Unlock()
 
@@ -233,7 +554,7 @@ func (m *Mouse) Up(button proto.InputMouseButton, clicks int64) error {
 
 	_, buttons := input.EncodeMouseButton(toButtons)
 
-	err := proto.InputDispatchMouseEvent{
+	err = proto.InputDispatchMouseEvent{
 		Type:       proto.InputDispatchMouseEventTypeMouseReleased,
 		Button:     button,
 		Buttons:    buttons,
@@ -250,9 +571,8 @@ func (m *Mouse) Up(button proto.InputMouseButton, clicks int64) error {
 
 // Click the button. It's the combination of Mouse.Down and Mouse.Up
 func (m *Mouse) Click(button proto.InputMouseButton) error {
-	if m.page.browser.trace {
-		defer m.page.Overlay(0, 0, 200, 0, "click "+string(button))()
-	}
+	defer m.page.tryTraceInput(fmt.Sprintf("%s click at (%.2f, %.2f)", button, m.x, m.y))()
+	defer m.tryTraceClickPoint()()
 	m.page.browser.trySlowmotion()
 
 	err := m.Down(button, 1)
@@ -263,6 +583,146 @@ func (m *Mouse) Click(button proto.InputMouseButton) error {
 	return m.Up(button, 1)
 }
 
+// DragPath holds the left button down, moves through each of the points and pauses holdAt at each of them,
+// then releases the button at the last point.
+func (m *Mouse) DragPath(points []proto.Point, holdAt time.Duration) error {
+	err := m.Down(proto.InputMouseButtonLeft, 1)
+	if err != nil {
+		return err
+	}
+
+	for _, pt := range points {
+		err = m.Move(pt.X, pt.Y, 1)
+		if err == nil {
+			err = sleepCtx(m.page.ctx, holdAt)
+		}
+		if err != nil {
+			_ = m.Up(proto.InputMouseButtonLeft, 1)
+			return err
+		}
+	}
+
+	return m.Up(proto.InputMouseButtonLeft, 1)
+}
+
+// sleepCtx sleeps for d, it returns the error of the ctx if the ctx ends before that
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Drag moves to the from point, holds the button down, moves to the to point with the steps, then releases the button.
+// If the move fails, the button will still be released to keep the state of the mouse clean.
+func (m *Mouse) Drag(from, to proto.Point, button proto.InputMouseButton, steps int) (err error) {
+	err = m.Move(from.X, from.Y, 1)
+	if err != nil {
+		return err
+	}
+
+	err = m.Down(button, 1)
+	if err != nil {
+		return err
+	}
+
+	err = m.Move(to.X, to.Y, steps)
+	if err != nil {
+		_ = m.Up(button, 1)
+		return err
+	}
+
+	return m.Up(button, 1)
+}
+
+// MouseEvent is a mouse event captured by Mouse.Record
+type MouseEvent struct {
+	// Type is one of mouseMoved, mousePressed, and mouseReleased
+	Type proto.InputDispatchMouseEventType
+
+	X float64
+	Y float64
+
+	Button proto.InputMouseButton
+
+	ClickCount int64
+
+	// Time since the recording started
+	Time time.Duration
+}
+
+// Record starts to capture the mouse moves, downs, and ups on the current document, call stop to get the events.
+// The recording will be lost if the page navigates.
+func (m *Mouse) Record() (stop func() ([]MouseEvent, error), err error) {
+	_, err = m.page.EvalWithOptions(jsHelper(js.RecordMouse, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return func() ([]MouseEvent, error) {
+		opts := jsHelper(js.StopRecordMouse, nil)
+		opts.ByValue = true
+		res, err := m.page.EvalWithOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		list := []MouseEvent{}
+		for _, e := range res.Value.Array() {
+			list = append(list, MouseEvent{
+				Type:       proto.InputDispatchMouseEventType(e.Get("type").String()),
+				X:          e.Get("x").Float(),
+				Y:          e.Get("y").Float(),
+				Button:     proto.InputMouseButton(e.Get("button").String()),
+				ClickCount: e.Get("clickCount").Int(),
+				Time:       time.Duration(e.Get("time").Float() * float64(time.Millisecond)),
+			})
+		}
+		return list, nil
+	}, nil
+}
+
+// Replay dispatches the events with the same timing as they were recorded.
+// Use the context of the page to cancel it, such as via Browser.Context.
+func (m *Mouse) Replay(events []MouseEvent) error {
+	start := time.Now()
+
+	for _, e := range events {
+		err := sleepCtx(m.page.ctx, e.Time-time.Since(start))
+		if err != nil {
+			return err
+		}
+
+		m.Lock()
+		moved := e.X != m.x || e.Y != m.y
+		m.Unlock()
+
+		if moved {
+			err = m.Move(e.X, e.Y, 1)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch e.Type {
+		case proto.InputDispatchMouseEventTypeMousePressed:
+			err = m.Down(e.Button, e.ClickCount)
+		case proto.InputDispatchMouseEventTypeMouseReleased:
+			err = m.Up(e.Button, e.ClickCount)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Touch presents a touch device, such as a hand with fingers, each finger is a proto.InputTouchPoint.
 // Touch events is stateless, we use the struct here only as a namespace to make the API style unified.
 type Touch struct {
@@ -308,9 +768,7 @@ func (t *Touch) Cancel() error {
 
 // Tap dispatches a touchstart and touchend event.
 func (t *Touch) Tap(x, y float64) error {
-	if t.page.browser.trace {
-		defer t.page.Overlay(0, 0, 200, 0, "touch")()
-	}
+	defer t.page.tryTraceInput(fmt.Sprintf("touch at (%.2f, %.2f)", x, y))()
 	t.page.browser.trySlowmotion()
 
 	p := &proto.InputTouchPoint{X: x, Y: y}
@@ -322,3 +780,22 @@ func (t *Touch) Tap(x, y float64) error {
 
 	return t.End()
 }
+
+// MultiTap dispatches a touchstart event with all the points as fingers, then a touchend event.
+// Such as a two-finger tap.
+func (t *Touch) MultiTap(points []proto.Point) error {
+	defer t.page.tryTraceInput(fmt.Sprintf("touch with %d fingers", len(points)))()
+	t.page.browser.trySlowmotion()
+
+	list := []*proto.InputTouchPoint{}
+	for i, pt := range points {
+		list = append(list, &proto.InputTouchPoint{X: pt.X, Y: pt.Y, ID: float64(i)})
+	}
+
+	err := t.Start(list...)
+	if err != nil {
+		return err
+	}
+
+	return t.End()
+}
@@ -29,11 +29,15 @@ const Monitor = {{.monitor}}
 
 // MonitorPage for rod
 const MonitorPage = {{.monitorPage}}
+
+// Stealth patches for rod
+const Stealth = {{.stealth}}
 `,
 		"helper", wrapHelperJS(helper),
 		"mousePointer", get("../../fixtures/mouse-pointer.svg"),
 		"monitor", get("monitor.html"),
 		"monitorPage", get("monitor-page.html"),
+		"stealth", get("stealth.js"),
 	)
 
 	utils.E(utils.OutputFile(slash("lib/assets/assets.go"), build))
@@ -73,6 +73,19 @@ const rod = {
     return list
   },
 
+  scrollParent() {
+    let p = this.parentElement
+    while (p) {
+      const style = getComputedStyle(p)
+      const overflow = style.overflow + style.overflowX + style.overflowY
+      if (/(auto|scroll)/.test(overflow)) {
+        return p
+      }
+      p = p.parentElement
+    }
+    return document.scrollingElement || document.documentElement
+  },
+
   containsElement(target) {
     var node = target
     while (node != null) {
@@ -112,6 +125,85 @@ const rod = {
     return true
   },
 
+  updateMouseTrail(id, x, y) {
+    let svg = document.getElementById(id)
+    if (!svg) {
+      if (!document.body) return
+      const ns = 'http://www.w3.org/2000/svg'
+      svg = document.createElementNS(ns, 'svg')
+      svg.id = id
+      svg.style =
+        'position: fixed; left: 0; top: 0; width: 100%; height: 100%; z-index: 2147483647; pointer-events: none;'
+      const line = document.createElementNS(ns, 'polyline')
+      line.setAttribute('fill', 'none')
+      line.setAttribute('stroke', 'red')
+      line.setAttribute('stroke-width', '2')
+      svg.appendChild(line)
+      document.body.appendChild(svg)
+    }
+    const line = svg.firstChild
+    const points = line.getAttribute('points') || ''
+    line.setAttribute('points', `${points} ${x},${y}`.trim())
+  },
+
+  dispatchWheel(x, y, deltaX, deltaY, deltaMode, modifiers) {
+    const target = document.elementFromPoint(x, y) || document
+    target.dispatchEvent(
+      new WheelEvent('wheel', {
+        bubbles: true,
+        cancelable: true,
+        composed: true,
+        view: window,
+        clientX: x,
+        clientY: y,
+        deltaX,
+        deltaY,
+        deltaMode,
+        altKey: !!(modifiers & 1),
+        ctrlKey: !!(modifiers & 2),
+        metaKey: !!(modifiers & 4),
+        shiftKey: !!(modifiers & 8),
+      })
+    )
+  },
+
+  recordMouse() {
+    const start = performance.now()
+    const buttons = ['left', 'middle', 'right', 'back', 'forward']
+    const types = {
+      mousemove: 'mouseMoved',
+      mousedown: 'mousePressed',
+      mouseup: 'mouseReleased',
+    }
+    const events = []
+    const handler = (e) => {
+      events.push({
+        type: types[e.type],
+        x: e.clientX,
+        y: e.clientY,
+        button: e.type === 'mousemove' ? 'none' : buttons[e.button],
+        clickCount: e.detail,
+        time: e.timeStamp - start,
+      })
+    }
+
+    for (const type in types) {
+      window.addEventListener(type, handler, true)
+    }
+    window.rodMouseRecorder = { types, events, handler }
+  },
+
+  stopRecordMouse() {
+    const recorder = window.rodMouseRecorder
+    if (!recorder) return []
+
+    for (const type in recorder.types) {
+      window.removeEventListener(type, recorder.handler, true)
+    }
+    delete window.rodMouseRecorder
+    return recorder.events
+  },
+
   async overlay(id, left, top, width, height, msg) {
     await rod.waitLoad()
 
@@ -210,20 +302,118 @@ const rod = {
     })
   },
 
+  waitImagesLoad() {
+    const el = ensureElement(this)
+    const list = Array.from(el.querySelectorAll('img'))
+    if (el.tagName === 'IMG') list.push(el)
+
+    // a broken image is also considered as loaded
+    return Promise.all(list.map((img) => rod.waitLoad.apply(img).catch(() => {})))
+  },
+
   inputEvent() {
     this.dispatchEvent(new Event('input', { bubbles: true }))
     this.dispatchEvent(new Event('change', { bubbles: true }))
   },
 
+  setValue(value) {
+    // the browser will clamp the value of range input by its min, max, and step
+    if (this.type === 'date' && !/^\d{4}-\d{2}-\d{2}$/.test(value)) {
+      const d = new Date(value)
+      if (isNaN(d)) throw new Error(`invalid date: ${value}`)
+      const pad = (n) => String(n).padStart(2, '0')
+      value = `${d.getFullYear()}-${pad(d.getMonth() + 1)}-${pad(d.getDate())}`
+    }
+
+    this.value = value
+    rod.inputEvent.call(this)
+  },
+
+  setFiles(files) {
+    const dt = new DataTransfer()
+    files.forEach((f) => {
+      const bin = atob(f.data)
+      const buf = new Uint8Array(bin.length)
+      for (let i = 0; i < bin.length; i++) buf[i] = bin.charCodeAt(i)
+      dt.items.add(new File([buf], f.name))
+    })
+    this.files = dt.files
+    rod.inputEvent.call(this)
+  },
+
   selectText(pattern) {
-    const m = this.value.match(new RegExp(pattern))
+    const text = this.setSelectionRange ? this.value : this.textContent
+    const m = text.match(new RegExp(pattern))
     if (m) {
-      this.setSelectionRange(m.index, m.index + m[0].length)
+      rod.selectRange.call(this, m.index, m.index + m[0].length)
     }
   },
 
+  selectionRect() {
+    // the selection inside the input-like elements isn't exposed as a range, use the box of the element
+    if (this.setSelectionRange) return this.getBoundingClientRect().toJSON()
+
+    const sel = window.getSelection()
+    if (sel.rangeCount === 0 || sel.isCollapsed) return null
+    return sel.getRangeAt(0).getBoundingClientRect().toJSON()
+  },
+
   selectAllText() {
-    this.select()
+    if (this.select) {
+      this.select()
+      return
+    }
+
+    // such as the contenteditable element
+    const range = document.createRange()
+    range.selectNodeContents(this)
+    const sel = window.getSelection()
+    sel.removeAllRanges()
+    sel.addRange(range)
+  },
+
+  clearRichText() {
+    rod.selectAllText.call(this)
+    document.execCommand('delete')
+
+    // the editor may leave a stray <br> after the deletion
+    if (this.childNodes.length > 0) {
+      const range = document.createRange()
+      range.selectNodeContents(this)
+      range.deleteContents()
+    }
+
+    this.dispatchEvent(new Event('input', { bubbles: true }))
+  },
+
+  selectRange(start, end) {
+    if (this.setSelectionRange) {
+      this.setSelectionRange(start, end)
+      return
+    }
+
+    // for contenteditable, find the text nodes that contain the offsets
+    const range = document.createRange()
+    const walker = document.createTreeWalker(this, NodeFilter.SHOW_TEXT)
+    let offset = 0
+    let node
+    range.setStart(this, 0)
+    range.setEnd(this, 0)
+    while ((node = walker.nextNode())) {
+      const len = node.textContent.length
+      if (start >= offset && start <= offset + len) {
+        range.setStart(node, start - offset)
+      }
+      if (end >= offset && end <= offset + len) {
+        range.setEnd(node, end - offset)
+        break
+      }
+      offset += len
+    }
+
+    const sel = window.getSelection()
+    sel.removeAllRanges()
+    sel.addRange(range)
   },
 
   select(selectors) {
@@ -244,20 +434,52 @@ const rod = {
   },
 
   visible() {
+    return rod.invisibleReason.apply(this) === ''
+  },
+
+  invisibleReason() {
+    // empty string means the element is visible
     const el = ensureElement(this)
     const box = el.getBoundingClientRect()
     const style = window.getComputedStyle(el)
-    return (
-      style.display !== 'none' &&
-      style.visibility !== 'hidden' &&
-      !!(box.top || box.bottom || box.width || box.height)
-    )
+
+    for (let p = el; p; p = p.parentElement) {
+      if (window.getComputedStyle(p).display === 'none') {
+        return p === el ? 'display: none' : 'display: none of an ancestor'
+      }
+    }
+
+    if (style.visibility === 'hidden') return 'visibility: hidden'
+
+    if (!(box.top || box.bottom || box.width || box.height)) return 'zero size'
+
+    return ''
   },
 
   invisible() {
     return !rod.visible.apply(this)
   },
 
+  perceivable() {
+    // the opacity is not inherited, the effective opacity is 0 if any ancestor's is 0
+    if (!rod.visible.apply(this)) return false
+    for (let p = ensureElement(this); p; p = p.parentElement) {
+      if (window.getComputedStyle(p).opacity === '0') return false
+    }
+    return true
+  },
+
+  intersectionRatio() {
+    const el = ensureElement(this)
+    return new Promise((resolve) => {
+      const observer = new IntersectionObserver((entries) => {
+        observer.disconnect()
+        resolve(entries[0].intersectionRatio)
+      })
+      observer.observe(el)
+    })
+  },
+
   text() {
     switch (this.tagName) {
       case 'INPUT':
@@ -275,6 +497,15 @@ const rod = {
   },
 
   resource() {
+    if (!('currentSrc' in this)) {
+      // such as the div with the css background-image
+      const m = getComputedStyle(this).backgroundImage.match(
+        /url\(\s*['"]?(.+?)['"]?\s*\)/
+      )
+      if (!m) throw new Error('no resource found for the element')
+      return new URL(m[1], document.baseURI).href
+    }
+
     return new Promise((resolve, reject) => {
       if (this.complete) {
         return resolve(this.currentSrc)
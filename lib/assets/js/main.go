@@ -18,12 +18,22 @@ const (
 	ElementR NameType = "elementR"
 	//Parents NameType function name
 	Parents NameType = "parents"
+	//ScrollParent NameType function name
+	ScrollParent NameType = "scrollParent"
 	//ContainsElement NameType function name
 	ContainsElement NameType = "containsElement"
 	//InitMouseTracer NameType function name
 	InitMouseTracer NameType = "initMouseTracer"
 	//UpdateMouseTracer NameType function name
 	UpdateMouseTracer NameType = "updateMouseTracer"
+	//UpdateMouseTrail NameType function name
+	UpdateMouseTrail NameType = "updateMouseTrail"
+	//DispatchWheel NameType function name
+	DispatchWheel NameType = "dispatchWheel"
+	//RecordMouse NameType function name
+	RecordMouse NameType = "recordMouse"
+	//StopRecordMouse NameType function name
+	StopRecordMouse NameType = "stopRecordMouse"
 	//Overlay NameType function name
 	Overlay NameType = "overlay"
 	//ElementOverlay NameType function name
@@ -34,18 +44,36 @@ const (
 	WaitIdle NameType = "waitIdle"
 	//WaitLoad NameType function name
 	WaitLoad NameType = "waitLoad"
+	//WaitImagesLoad NameType function name
+	WaitImagesLoad NameType = "waitImagesLoad"
 	//InputEvent NameType function name
 	InputEvent NameType = "inputEvent"
+	//SetValue NameType function name
+	SetValue NameType = "setValue"
+	//SetFiles NameType function name
+	SetFiles NameType = "setFiles"
 	//SelectText NameType function name
 	SelectText NameType = "selectText"
+	//SelectionRect NameType function name
+	SelectionRect NameType = "selectionRect"
 	//SelectAllText NameType function name
 	SelectAllText NameType = "selectAllText"
+	//ClearRichText NameType function name
+	ClearRichText NameType = "clearRichText"
+	//SelectRange NameType function name
+	SelectRange NameType = "selectRange"
 	//Select NameType function name
 	Select NameType = "select"
 	//Visible NameType function name
 	Visible NameType = "visible"
+	//InvisibleReason NameType function name
+	InvisibleReason NameType = "invisibleReason"
 	//Invisible NameType function name
 	Invisible NameType = "invisible"
+	//Perceivable NameType function name
+	Perceivable NameType = "perceivable"
+	//IntersectionRatio NameType function name
+	IntersectionRatio NameType = "intersectionRatio"
 	//Text NameType function name
 	Text NameType = "text"
 	//Resource NameType function name
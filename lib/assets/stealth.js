@@ -0,0 +1,56 @@
+// A best-effort set of patches to make the headless browser look more like a normal one.
+// It's injected before any script of the page runs.
+;(() => {
+  const define = (obj, name, get) => {
+    try {
+      Object.defineProperty(obj, name, { get, configurable: true })
+    } catch (e) {
+      null
+    }
+  }
+
+  define(Navigator.prototype, 'webdriver', () => false)
+
+  if (!navigator.languages || navigator.languages.length === 0) {
+    define(Navigator.prototype, 'languages', () => ['en-US', 'en'])
+  }
+
+  if (navigator.plugins.length === 0) {
+    const plugins = [
+      {
+        name: 'Chrome PDF Plugin',
+        filename: 'internal-pdf-viewer',
+        description: 'Portable Document Format',
+      },
+      {
+        name: 'Chrome PDF Viewer',
+        filename: 'mhjfbmdgcfjbbpaeojofohoefgiehjai',
+        description: '',
+      },
+      {
+        name: 'Native Client',
+        filename: 'internal-nacl-plugin',
+        description: '',
+      },
+    ]
+    plugins.item = (i) => plugins[i] || null
+    plugins.namedItem = (name) => plugins.find((p) => p.name === name) || null
+    plugins.refresh = () => {}
+    define(Navigator.prototype, 'plugins', () => plugins)
+  }
+
+  if (!window.chrome) {
+    window.chrome = {}
+  }
+  if (!window.chrome.runtime) {
+    window.chrome.runtime = {}
+  }
+
+  const query = navigator.permissions && navigator.permissions.query
+  if (query) {
+    navigator.permissions.query = (params) =>
+      params.name === 'notifications'
+        ? Promise.resolve({ state: Notification.permission })
+        : query.call(navigator.permissions, params)
+  }
+})()
@@ -47,19 +47,37 @@ type Key struct {
 	Print bool
 }
 
+// Modifiers is the map for the modifier keys and their flags used by the Input.dispatchKeyEvent
+var Modifiers = map[rune]int64{
+	Alt:     1,
+	Control: 2,
+	Meta:    4,
+	Shift:   8,
+}
+
 // Encode encodes a keyDown, char, and keyUp sequence for the specified rune.
+// It returns nil for the unknown keys, such as an emoji, they should be input as text.
 func Encode(r rune) []*proto.InputDispatchKeyEvent {
 	// force \n -> \r
 	if r == '\n' {
 		r = '\r'
 	}
 
-	// if not known key, encode as unidentified
-	v := Keys[r]
+	v, has := Keys[r]
+	if !has {
+		return nil
+	}
+
+	return EncodeKey(r, v, 0)
+}
 
+// EncodeKey encodes a keyDown, char, and keyUp sequence for the specified rune with the key info v.
+// The modifiers will be sent together with the modifiers of the key, such as the Shift.
+func EncodeKey(r rune, v *Key, modifiers int64) []*proto.InputDispatchKeyEvent {
 	// create
 	keyDown := proto.InputDispatchKeyEvent{
 		Type:                  "keyDown",
+		Modifiers:             modifiers,
 		Key:                   v.Key,
 		Code:                  v.Code,
 		NativeVirtualKeyCode:  v.Native,
@@ -0,0 +1,135 @@
+// Package layout contains keyboard layouts. A layout decides which physical key and modifiers
+// will be pressed to type a character, such as the "@" is Shift+2 on US layout but AltGr+Q on German layout.
+// It matters for the sites that read the event.code rather than the event.key.
+package layout
+
+import (
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Key of a layout
+type Key struct {
+	input.Key
+
+	// AltGr indicates whether or not the AltGr modifier should be sent, it's the same as Ctrl+Alt
+	AltGr bool
+}
+
+// Layout maps the character to the key that produces it
+type Layout map[rune]*Key
+
+// US layout, the same as the input.Keys
+var US = func() Layout {
+	l := Layout{}
+	for r, k := range input.Keys {
+		l[r] = &Key{Key: *k}
+	}
+	return l
+}()
+
+// UK layout
+var UK = US.Extend(Layout{
+	'"':  printable("Digit2", "\"", "2", 50, true, false),
+	'@':  printable("Quote", "@", "'", 192, true, false),
+	'£':  printable("Digit3", "£", "3", 51, true, false),
+	'#':  printable("Backslash", "#", "#", 222, false, false),
+	'~':  printable("Backslash", "~", "#", 222, true, false),
+	'\'': printable("Quote", "'", "'", 192, false, false),
+	'\\': printable("IntlBackslash", "\\", "\\", 220, false, false),
+	'|':  printable("IntlBackslash", "|", "\\", 220, true, false),
+	'`':  printable("Backquote", "`", "`", 223, false, false),
+	'¬':  printable("Backquote", "¬", "`", 223, true, false),
+})
+
+// German layout, the QWERTZ layout
+var German = US.Extend(Layout{
+	'y':  printable("KeyZ", "y", "y", 89, false, false),
+	'Y':  printable("KeyZ", "Y", "y", 89, true, false),
+	'z':  printable("KeyY", "z", "z", 90, false, false),
+	'Z':  printable("KeyY", "Z", "z", 90, true, false),
+	'"':  printable("Digit2", "\"", "2", 50, true, false),
+	'§':  printable("Digit3", "§", "3", 51, true, false),
+	'&':  printable("Digit6", "&", "6", 54, true, false),
+	'/':  printable("Digit7", "/", "7", 55, true, false),
+	'(':  printable("Digit8", "(", "8", 56, true, false),
+	')':  printable("Digit9", ")", "9", 57, true, false),
+	'=':  printable("Digit0", "=", "0", 48, true, false),
+	'{':  printable("Digit7", "{", "7", 55, false, true),
+	'[':  printable("Digit8", "[", "8", 56, false, true),
+	']':  printable("Digit9", "]", "9", 57, false, true),
+	'}':  printable("Digit0", "}", "0", 48, false, true),
+	'ß':  printable("Minus", "ß", "ß", 219, false, false),
+	'?':  printable("Minus", "?", "ß", 219, true, false),
+	'\\': printable("Minus", "\\", "ß", 219, false, true),
+	'ü':  printable("BracketLeft", "ü", "ü", 186, false, false),
+	'Ü':  printable("BracketLeft", "Ü", "ü", 186, true, false),
+	'+':  printable("BracketRight", "+", "+", 187, false, false),
+	'*':  printable("BracketRight", "*", "+", 187, true, false),
+	'~':  printable("BracketRight", "~", "+", 187, false, true),
+	'ö':  printable("Semicolon", "ö", "ö", 192, false, false),
+	'Ö':  printable("Semicolon", "Ö", "ö", 192, true, false),
+	'ä':  printable("Quote", "ä", "ä", 222, false, false),
+	'Ä':  printable("Quote", "Ä", "ä", 222, true, false),
+	'#':  printable("Backslash", "#", "#", 191, false, false),
+	'\'': printable("Backslash", "'", "#", 191, true, false),
+	';':  printable("Comma", ";", ",", 188, true, false),
+	':':  printable("Period", ":", ".", 190, true, false),
+	'-':  printable("Slash", "-", "-", 189, false, false),
+	'_':  printable("Slash", "_", "-", 189, true, false),
+	'<':  printable("IntlBackslash", "<", "<", 226, false, false),
+	'>':  printable("IntlBackslash", ">", "<", 226, true, false),
+	'|':  printable("IntlBackslash", "|", "<", 226, false, true),
+	'@':  printable("KeyQ", "@", "q", 81, false, true),
+	'€':  printable("KeyE", "€", "e", 69, false, true),
+	'°':  printable("Backquote", "°", "^", 220, true, false),
+})
+
+// Extend returns a new layout that overrides the keys of l with the keys
+func (l Layout) Extend(keys Layout) Layout {
+	n := Layout{}
+	for r, k := range l {
+		n[r] = k
+	}
+	for r, k := range keys {
+		n[r] = k
+	}
+	return n
+}
+
+// Encode encodes a keyDown, char, and keyUp sequence for the specified rune.
+// If the rune is not in the layout, the input.Encode will be used, so nil will be returned for unknown keys.
+func (l Layout) Encode(r rune) []*proto.InputDispatchKeyEvent {
+	// force \n -> \r
+	if r == '\n' {
+		r = '\r'
+	}
+
+	k, has := l[r]
+	if !has {
+		return input.Encode(r)
+	}
+
+	var modifiers int64
+	if k.AltGr {
+		modifiers = 1 | 2 // Alt and Ctrl
+	}
+
+	return input.EncodeKey(r, &k.Key, modifiers)
+}
+
+func printable(code, key, unmodified string, vk int64, shift, altGr bool) *Key {
+	return &Key{
+		Key: input.Key{
+			Code:       code,
+			Key:        key,
+			Text:       key,
+			Unmodified: unmodified,
+			Native:     vk,
+			Windows:    vk,
+			Shift:      shift,
+			Print:      true,
+		},
+		AltGr: altGr,
+	}
+}
@@ -219,6 +219,21 @@ func (q DOMQuad) CenterY() float64 {
 	return q.Y() + q.Height()/2
 }
 
+// AttributeMap pairs the flat Attributes list, such as ["id", "foo", "class", "bar"], into a map
+func (n *DOMNode) AttributeMap() map[string]string {
+	m := make(map[string]string, len(n.Attributes)/2)
+	for i := 0; i+1 < len(n.Attributes); i += 2 {
+		m[n.Attributes[i]] = n.Attributes[i+1]
+	}
+	return m
+}
+
+// Point is a position on the page, such as the position of the mouse
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
 // MoveTo X and Y to x and y
 func (p *InputTouchPoint) MoveTo(x, y float64) {
 	p.X = x
@@ -142,3 +142,10 @@ func TestInputTouchPointMoveTo(t *testing.T) {
 	assert.EqualValues(t, 1, p.X)
 	assert.EqualValues(t, 2, p.Y)
 }
+
+func TestDOMNodeAttributeMap(t *testing.T) {
+	n := &proto.DOMNode{Attributes: []string{"id", "foo", "class", "bar"}}
+	assert.Equal(t, map[string]string{"id": "foo", "class": "bar"}, n.AttributeMap())
+
+	assert.Equal(t, map[string]string{}, (&proto.DOMNode{}).AttributeMap())
+}
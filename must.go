@@ -106,6 +106,24 @@ func (p *Page) MustSetCookies(cookies ...*proto.NetworkCookieParam) *Page {
 	return p
 }
 
+// MustClearCookies is similar to ClearCookies
+func (p *Page) MustClearCookies() *Page {
+	utils.E(p.ClearCookies())
+	return p
+}
+
+// MustDeleteCookie is similar to DeleteCookie
+func (p *Page) MustDeleteCookie(name, url string) *Page {
+	utils.E(p.DeleteCookie(name, url))
+	return p
+}
+
+// MustClearStorage is similar to ClearStorage
+func (p *Page) MustClearStorage(types ...proto.StorageStorageType) *Page {
+	utils.E(p.ClearStorage(types))
+	return p
+}
+
 // MustSetExtraHeaders is similar to SetExtraHeaders
 func (p *Page) MustSetExtraHeaders(dict ...string) (cleanup func()) {
 	cleanup, err := p.SetExtraHeaders(dict)
@@ -119,6 +137,42 @@ func (p *Page) MustSetUserAgent(req *proto.NetworkSetUserAgentOverride) *Page {
 	return p
 }
 
+// MustSetBypassCSP is similar to SetBypassCSP
+func (p *Page) MustSetBypassCSP(enabled bool) *Page {
+	utils.E(p.SetBypassCSP(enabled))
+	return p
+}
+
+// MustSetTimezone is similar to SetTimezone
+func (p *Page) MustSetTimezone(tz string) *Page {
+	utils.E(p.SetTimezone(tz))
+	return p
+}
+
+// MustSetLocale is similar to SetLocale
+func (p *Page) MustSetLocale(locale string) *Page {
+	utils.E(p.SetLocale(locale))
+	return p
+}
+
+// MustSetJavaScriptEnabled is similar to SetJavaScriptEnabled
+func (p *Page) MustSetJavaScriptEnabled(enabled bool) *Page {
+	utils.E(p.SetJavaScriptEnabled(enabled))
+	return p
+}
+
+// MustSetCacheDisabled is similar to SetCacheDisabled
+func (p *Page) MustSetCacheDisabled(disabled bool) *Page {
+	utils.E(p.SetCacheDisabled(disabled))
+	return p
+}
+
+// MustSetDownloadPath is similar to SetDownloadPath
+func (p *Page) MustSetDownloadPath(dir string) *Page {
+	utils.E(p.SetDownloadPath(dir))
+	return p
+}
+
 // MustNavigate is similar to Navigate
 func (p *Page) MustNavigate(url string) *Page {
 	utils.E(p.Navigate(url))
@@ -194,6 +248,13 @@ func (p *Page) MustWindowNormal() *Page {
 	return p
 }
 
+// MustViewport is similar to Viewport
+func (p *Page) MustViewport() (width, height int, scale float64, mobile bool) {
+	width, height, scale, mobile, err := p.Viewport()
+	utils.E(err)
+	return
+}
+
 // MustSetViewport is similar to SetViewport
 func (p *Page) MustSetViewport(width, height int64, deviceScaleFactor float64, mobile bool) *Page {
 	utils.E(p.SetViewport(&proto.EmulationSetDeviceMetricsOverride{
@@ -230,6 +291,20 @@ func (p *Page) MustHandleDialog(accept bool, promptText string) (wait func()) {
 	}
 }
 
+// MustLayoutMetrics is similar to LayoutMetrics
+func (p *Page) MustLayoutMetrics() *proto.PageGetLayoutMetricsResult {
+	res, err := p.LayoutMetrics()
+	utils.E(err)
+	return res
+}
+
+// MustAXTree is similar to AXTree
+func (p *Page) MustAXTree() *AXTreeNode {
+	root, err := p.AXTree()
+	utils.E(err)
+	return root
+}
+
 // MustScreenshot is similar to Screenshot
 func (p *Page) MustScreenshot(toFile ...string) []byte {
 	bin, err := p.Screenshot(false, &proto.PageCaptureScreenshot{})
@@ -246,6 +321,13 @@ func (p *Page) MustScreenshotFullPage(toFile ...string) []byte {
 	return bin
 }
 
+// MustGetResource is similar to GetResource
+func (p *Page) MustGetResource(url string) []byte {
+	bin, err := p.GetResource(url)
+	utils.E(err)
+	return bin
+}
+
 // MustPDF is similar to PDF
 func (p *Page) MustPDF(toFile ...string) []byte {
 	r, err := p.PDF(&proto.PagePrintToPDF{})
@@ -257,6 +339,14 @@ func (p *Page) MustPDF(toFile ...string) []byte {
 	return bin
 }
 
+// MustCaptureSnapshot is similar to CaptureSnapshot
+func (p *Page) MustCaptureSnapshot(toFile ...string) string {
+	data, err := p.CaptureSnapshot()
+	utils.E(err)
+	utils.E(saveFile(saveFileTypeSnapshot, []byte(data), toFile))
+	return data
+}
+
 // MustGetDownloadFile is similar to GetDownloadFile
 func (p *Page) MustGetDownloadFile(pattern string) func() []byte {
 	wait := p.GetDownloadFile(pattern, "", http.DefaultClient)
@@ -294,11 +384,48 @@ func (p *Page) MustWaitNavigation() func() {
 	return p.WaitNavigation(proto.PageLifecycleEventNameNetworkAlmostIdle)
 }
 
+// MustWaitLifecycle is similar to WaitLifecycle
+func (p *Page) MustWaitLifecycle(name proto.PageLifecycleEventName) (wait func()) {
+	wait, err := p.WaitLifecycle(name)
+	utils.E(err)
+	return wait
+}
+
+// MustWaitDOMStable is similar to WaitDOMStable
+func (p *Page) MustWaitDOMStable() (wait func()) {
+	wait, err := p.WaitDOMStable()
+	utils.E(err)
+	return wait
+}
+
 // MustWaitRequestIdle is similar to WaitRequestIdle
 func (p *Page) MustWaitRequestIdle(excludes ...string) (wait func()) {
 	return p.WaitRequestIdle(300*time.Millisecond, nil, excludes)
 }
 
+// MustStartHAR is similar to StartHAR
+func (p *Page) MustStartHAR() *Page {
+	utils.E(p.StartHAR())
+	return p
+}
+
+// MustStopHAR is similar to StopHAR
+func (p *Page) MustStopHAR() *HAR {
+	har, err := p.StopHAR()
+	utils.E(err)
+	return har
+}
+
+// MustWaitRequest is similar to WaitRequest
+func (p *Page) MustWaitRequest(pattern string) (wait func() (*proto.NetworkResponseReceived, []byte)) {
+	w := p.WaitRequest(pattern)
+	return func() (*proto.NetworkResponseReceived, []byte) {
+		res, body, err := w()
+		utils.E(err)
+		return res, body
+	}
+}
+
 // MustWaitIdle is similar to WaitIdle
 func (p *Page) MustWaitIdle() *Page {
 	utils.E(p.WaitIdle(time.Minute))
@@ -329,6 +456,19 @@ func (p *Page) MustEvalOnNewDocument(js string) {
 	utils.E(err)
 }
 
+// MustStealthInit is similar to StealthInit
+func (p *Page) MustStealthInit() *Page {
+	utils.E(p.StealthInit())
+	return p
+}
+
+// MustExposeValues is similar to ExposeValues
+func (p *Page) MustExposeValues(name string, values map[string]interface{}) *ExposedValues {
+	ev, err := p.ExposeValues(name, values)
+	utils.E(err)
+	return ev
+}
+
 // MustExpose is similar to Expose
 func (p *Page) MustExpose(name string) (callback chan string, stop func()) {
 	c, s, err := p.Expose(name)
@@ -343,6 +483,20 @@ func (p *Page) MustEval(js string, params ...interface{}) proto.JSON {
 	return res.Value
 }
 
+// MustEvalOnSelector is similar to EvalOnSelector
+func (p *Page) MustEvalOnSelector(selector, js string, params ...interface{}) proto.JSON {
+	res, err := p.EvalOnSelector(selector, js, params...)
+	utils.E(err)
+	return res.Value
+}
+
+// MustEvalOnSelectorAll is similar to EvalOnSelectorAll
+func (p *Page) MustEvalOnSelectorAll(selector, js string, params ...interface{}) proto.JSON {
+	res, err := p.EvalOnSelectorAll(selector, js, params...)
+	utils.E(err)
+	return res.Value
+}
+
 // MustWait is similar to Wait
 func (p *Page) MustWait(js string, params ...interface{}) {
 	utils.E(p.Wait("", js, params))
@@ -443,6 +597,13 @@ func (p *Page) MustElementByJS(js string, params ...interface{}) *Element {
 	return el
 }
 
+// MustFrameByName is similar to FrameByName
+func (p *Page) MustFrameByName(name string) *Page {
+	f, err := p.FrameByName(name)
+	utils.E(err)
+	return f
+}
+
 // MustElements is similar to Elements
 func (p *Page) MustElements(selector string) Elements {
 	list, err := p.Elements(selector)
@@ -511,6 +672,12 @@ func (m *Mouse) MustScroll(x, y float64) *Mouse {
 	return m
 }
 
+// MustWheel is similar to Wheel
+func (m *Mouse) MustWheel(deltaX, deltaY float64, mode WheelMode) *Mouse {
+	utils.E(m.Wheel(deltaX, deltaY, mode))
+	return m
+}
+
 // MustDown is similar to Down
 func (m *Mouse) MustDown(button proto.InputMouseButton) *Mouse {
 	utils.E(m.Down(button, 1))
@@ -529,6 +696,35 @@ func (m *Mouse) MustClick(button proto.InputMouseButton) *Mouse {
 	return m
 }
 
+// MustDragPath is similar to DragPath
+func (m *Mouse) MustDragPath(points []proto.Point, holdAt time.Duration) *Mouse {
+	utils.E(m.DragPath(points, holdAt))
+	return m
+}
+
+// MustDrag is similar to Drag
+func (m *Mouse) MustDrag(from, to proto.Point, button proto.InputMouseButton, steps int) *Mouse {
+	utils.E(m.Drag(from, to, button, steps))
+	return m
+}
+
+// MustRecord is similar to Record
+func (m *Mouse) MustRecord() (stop func() []MouseEvent) {
+	s, err := m.Record()
+	utils.E(err)
+	return func() []MouseEvent {
+		list, err := s()
+		utils.E(err)
+		return list
+	}
+}
+
+// MustReplay is similar to Replay
+func (m *Mouse) MustReplay(events []MouseEvent) *Mouse {
+	utils.E(m.Replay(events))
+	return m
+}
+
 // MustDown is similar to Down
 func (k *Keyboard) MustDown(key rune) *Keyboard {
 	utils.E(k.Down(key))
@@ -547,6 +743,24 @@ func (k *Keyboard) MustPress(key rune) *Keyboard {
 	return k
 }
 
+// MustPressRepeat is similar to PressRepeat
+func (k *Keyboard) MustPressRepeat(key rune, count int, interval time.Duration) *Keyboard {
+	utils.E(k.PressRepeat(key, count, interval))
+	return k
+}
+
+// MustPressWithModifiers is similar to PressWithModifiers
+func (k *Keyboard) MustPressWithModifiers(key rune, modifiers ...rune) *Keyboard {
+	utils.E(k.PressWithModifiers(key, modifiers...))
+	return k
+}
+
+// MustShortcut is similar to Shortcut
+func (k *Keyboard) MustShortcut(combo string) *Keyboard {
+	utils.E(k.Shortcut(combo))
+	return k
+}
+
 // MustInsertText is similar to InsertText
 func (k *Keyboard) MustInsertText(text string) *Keyboard {
 	utils.E(k.InsertText(text))
@@ -583,6 +797,12 @@ func (t *Touch) MustTap(x, y float64) *Touch {
 	return t
 }
 
+// MustMultiTap is similar to MultiTap
+func (t *Touch) MustMultiTap(points ...proto.Point) *Touch {
+	utils.E(t.MultiTap(points))
+	return t
+}
+
 // MustDescribe is similar to Describe
 func (el *Element) MustDescribe() *proto.DOMNode {
 	node, err := el.Describe(1, false)
@@ -590,6 +810,13 @@ func (el *Element) MustDescribe() *proto.DOMNode {
 	return node
 }
 
+// MustDescribeAttributes is similar to DescribeAttributes
+func (el *Element) MustDescribeAttributes() map[string]string {
+	attrs, err := el.DescribeAttributes()
+	utils.E(err)
+	return attrs
+}
+
 // MustNodeID is similar to NodeID
 func (el *Element) MustNodeID() proto.DOMNodeID {
 	id, err := el.NodeID()
@@ -597,6 +824,27 @@ func (el *Element) MustNodeID() proto.DOMNodeID {
 	return id
 }
 
+// MustAXNode is similar to AXNode
+func (el *Element) MustAXNode() *proto.AccessibilityAXNode {
+	node, err := el.AXNode()
+	utils.E(err)
+	return node
+}
+
+// MustAXRole is similar to AXRole
+func (el *Element) MustAXRole() string {
+	role, err := el.AXRole()
+	utils.E(err)
+	return role
+}
+
+// MustAXName is similar to AXName
+func (el *Element) MustAXName() string {
+	name, err := el.AXName()
+	utils.E(err)
+	return name
+}
+
 // MustShadowRoot is similar to ShadowRoot
 func (el *Element) MustShadowRoot() *Element {
 	node, err := el.ShadowRoot()
@@ -604,6 +852,13 @@ func (el *Element) MustShadowRoot() *Element {
 	return node
 }
 
+// MustQueryShadow is similar to QueryShadow
+func (el *Element) MustQueryShadow(selectors ...string) *Element {
+	node, err := el.QueryShadow(selectors...)
+	utils.E(err)
+	return node
+}
+
 // MustFrame is similar to Frame
 func (el *Element) MustFrame() *Page {
 	p, err := el.Frame()
@@ -611,30 +866,87 @@ func (el *Element) MustFrame() *Page {
 	return p
 }
 
+// MustFrameReady is similar to FrameReady
+func (el *Element) MustFrameReady() *Page {
+	p, err := el.FrameReady()
+	utils.E(err)
+	return p
+}
+
 // MustFocus is similar to Focus
 func (el *Element) MustFocus() *Element {
 	utils.E(el.Focus())
 	return el
 }
 
+// MustFocusNoScroll is similar to FocusNoScroll
+func (el *Element) MustFocusNoScroll() *Element {
+	utils.E(el.FocusNoScroll())
+	return el
+}
+
 // MustScrollIntoView is similar to ScrollIntoView
 func (el *Element) MustScrollIntoView() *Element {
 	utils.E(el.ScrollIntoView())
 	return el
 }
 
+// MustScrollPercentY is similar to ScrollPercentY
+func (el *Element) MustScrollPercentY() float64 {
+	percent, err := el.ScrollPercentY()
+	utils.E(err)
+	return percent
+}
+
+// MustScrollToPercentY is similar to ScrollToPercentY
+func (el *Element) MustScrollToPercentY(percent float64) *Element {
+	utils.E(el.ScrollToPercentY(percent))
+	return el
+}
+
 // MustHover is similar to Hover
 func (el *Element) MustHover() *Element {
 	utils.E(el.Hover())
 	return el
 }
 
+// MustHoverFor is similar to HoverFor
+func (el *Element) MustHoverFor(d time.Duration) *Element {
+	utils.E(el.HoverFor(d))
+	return el
+}
+
+// MustLeave is similar to Leave
+func (el *Element) MustLeave() *Element {
+	utils.E(el.Leave())
+	return el
+}
+
 // MustClick is similar to Click
 func (el *Element) MustClick() *Element {
 	utils.E(el.Click(proto.InputMouseButtonLeft))
 	return el
 }
 
+// MustClickJS is similar to ClickJS
+func (el *Element) MustClickJS() *Element {
+	utils.E(el.ClickJS())
+	return el
+}
+
+// MustClickAndWaitNav is similar to ClickAndWaitNav
+func (el *Element) MustClickAndWaitNav() *Element {
+	utils.E(el.ClickAndWaitNav(proto.InputMouseButtonLeft))
+	return el
+}
+
+// MustDoubleClickSelect is similar to DoubleClickSelect
+func (el *Element) MustDoubleClickSelect() string {
+	text, err := el.DoubleClickSelect()
+	utils.E(err)
+	return text
+}
+
 // MustTap is similar to Tap
 func (el *Element) MustTap() *Element {
 	utils.E(el.Tap())
@@ -657,30 +969,73 @@ func (el *Element) MustPress(key rune) *Element {
 	return el
 }
 
+// MustPressRepeat is similar to PressRepeat
+func (el *Element) MustPressRepeat(key rune, count int, interval time.Duration) *Element {
+	utils.E(el.PressRepeat(key, count, interval))
+	return el
+}
+
+// MustPressWithModifiers is similar to PressWithModifiers
+func (el *Element) MustPressWithModifiers(key rune, modifiers ...rune) *Element {
+	utils.E(el.PressWithModifiers(key, modifiers...))
+	return el
+}
+
 // MustSelectText is similar to SelectText
 func (el *Element) MustSelectText(regex string) *Element {
 	utils.E(el.SelectText(regex))
 	return el
 }
 
+// MustSelectionRect is similar to SelectionRect
+func (el *Element) MustSelectionRect() *proto.DOMRect {
+	rect, err := el.SelectionRect()
+	utils.E(err)
+	return rect
+}
+
 // MustSelectAllText is similar to SelectAllText
 func (el *Element) MustSelectAllText() *Element {
 	utils.E(el.SelectAllText())
 	return el
 }
 
+// MustClearRichText is similar to ClearRichText
+func (el *Element) MustClearRichText() *Element {
+	utils.E(el.ClearRichText())
+	return el
+}
+
+// MustSelectRange is similar to SelectRange
+func (el *Element) MustSelectRange(start, end int) *Element {
+	utils.E(el.SelectRange(start, end))
+	return el
+}
+
 // MustInput is similar to Input
 func (el *Element) MustInput(text string) *Element {
 	utils.E(el.Input(text))
 	return el
 }
 
+// MustInputSecret is similar to InputSecret
+func (el *Element) MustInputSecret(text string) *Element {
+	utils.E(el.InputSecret(text))
+	return el
+}
+
 // MustBlur is similar to Blur
 func (el *Element) MustBlur() *Element {
 	utils.E(el.Blur())
 	return el
 }
 
+// MustSetValue is similar to SetValue
+func (el *Element) MustSetValue(value string) *Element {
+	utils.E(el.SetValue(value))
+	return el
+}
+
 // MustSelect is similar to Select
 func (el *Element) MustSelect(selectors ...string) *Element {
 	utils.E(el.Select(selectors))
@@ -694,6 +1049,13 @@ func (el *Element) MustMatches(selector string) bool {
 	return res
 }
 
+// MustMatchesAny is similar to MatchesAny
+func (el *Element) MustMatchesAny(selectors ...string) string {
+	res, err := el.MatchesAny(selectors)
+	utils.E(err)
+	return res
+}
+
 // MustAttribute is similar to Attribute
 func (el *Element) MustAttribute(name string) *string {
 	attr, err := el.Attribute(name)
@@ -701,6 +1063,13 @@ func (el *Element) MustAttribute(name string) *string {
 	return attr
 }
 
+// MustAttributeNS is similar to AttributeNS
+func (el *Element) MustAttributeNS(ns, name string) *string {
+	attr, err := el.AttributeNS(ns, name)
+	utils.E(err)
+	return attr
+}
+
 // MustProperty is similar to Property
 func (el *Element) MustProperty(name string) proto.JSON {
 	prop, err := el.Property(name)
@@ -721,6 +1090,20 @@ func (el *Element) MustSetFiles(paths ...string) *Element {
 	return el
 }
 
+// MustSetFilesFromReaders is similar to SetFilesFromReaders
+func (el *Element) MustSetFilesFromReaders(files ...NamedReader) *Element {
+	utils.E(el.SetFilesFromReaders(files))
+	return el
+}
+
+// MustWaitFileRead is similar to WaitFileRead
+func (el *Element) MustWaitFileRead(count int) (wait func()) {
+	w := el.WaitFileRead(count)
+	return func() {
+		utils.E(w())
+	}
+}
+
 // MustText is similar to Text
 func (el *Element) MustText() string {
 	s, err := el.Text()
@@ -728,6 +1111,13 @@ func (el *Element) MustText() string {
 	return s
 }
 
+// MustOwnText is similar to OwnText
+func (el *Element) MustOwnText() string {
+	s, err := el.OwnText()
+	utils.E(err)
+	return s
+}
+
 // MustHTML is similar to HTML
 func (el *Element) MustHTML() string {
 	s, err := el.HTML()
@@ -735,6 +1125,13 @@ func (el *Element) MustHTML() string {
 	return s
 }
 
+// MustIsStale is similar to IsStale
+func (el *Element) MustIsStale() bool {
+	stale, err := el.IsStale()
+	utils.E(err)
+	return stale
+}
+
 // MustVisible is similar to Visible
 func (el *Element) MustVisible() bool {
 	v, err := el.Visible()
@@ -742,6 +1139,20 @@ func (el *Element) MustVisible() bool {
 	return v
 }
 
+// MustPerceivable is similar to Perceivable
+func (el *Element) MustPerceivable() bool {
+	v, err := el.Perceivable()
+	utils.E(err)
+	return v
+}
+
+// MustIntersectionRatio is similar to IntersectionRatio
+func (el *Element) MustIntersectionRatio() float64 {
+	r, err := el.IntersectionRatio()
+	utils.E(err)
+	return r
+}
+
 // MustWaitLoad is similar to WaitLoad
 func (el *Element) MustWaitLoad() *Element {
 	utils.E(el.WaitLoad())
@@ -754,6 +1165,19 @@ func (el *Element) MustWaitStable() *Element {
 	return el
 }
 
+// MustWaitStableShape is similar to WaitStableShape
+func (el *Element) MustWaitStableShape() []proto.DOMQuad {
+	shape, err := el.WaitStableShape(100 * time.Millisecond)
+	utils.E(err)
+	return shape
+}
+
+// MustWaitStableThreshold is similar to WaitStableThreshold
+func (el *Element) MustWaitStableThreshold(pixels float64) *Element {
+	utils.E(el.WaitStableThreshold(100*time.Millisecond, pixels))
+	return el
+}
+
 // MustWait is similar to Wait
 func (el *Element) MustWait(js string, params ...interface{}) *Element {
 	utils.E(el.Wait(js, params))
@@ -766,6 +1190,12 @@ func (el *Element) MustWaitVisible() *Element {
 	return el
 }
 
+// MustWaitPerceivable is similar to WaitPerceivable
+func (el *Element) MustWaitPerceivable() *Element {
+	utils.E(el.WaitPerceivable())
+	return el
+}
+
 // MustWaitInvisible is similar to WaitInvisible
 func (el *Element) MustWaitInvisible() *Element {
 	utils.E(el.WaitInvisible())
@@ -779,6 +1209,13 @@ func (el *Element) MustBox() *proto.DOMBoxModel {
 	return box
 }
 
+// MustWaitBox is similar to WaitBox
+func (el *Element) MustWaitBox() *proto.DOMBoxModel {
+	box, err := el.WaitBox()
+	utils.E(err)
+	return box
+}
+
 // MustShape is similar to Shape
 func (el *Element) MustShape() []proto.DOMQuad {
 	shape, err := el.Shape()
@@ -808,6 +1245,22 @@ func (el *Element) MustScreenshot(toFile ...string) []byte {
 	return bin
 }
 
+// MustScreenshotRegion is similar to ScreenshotRegion
+func (el *Element) MustScreenshotRegion(x, y, width, height float64, toFile ...string) []byte {
+	bin, err := el.ScreenshotRegion(x, y, width, height, proto.PageCaptureScreenshotFormatPng, 0)
+	utils.E(err)
+	utils.E(saveFile(saveFileTypeScreenshot, bin, toFile))
+	return bin
+}
+
+// MustScreenshotWhenLoaded is similar to ScreenshotWhenLoaded
+func (el *Element) MustScreenshotWhenLoaded(toFile ...string) []byte {
+	bin, err := el.ScreenshotWhenLoaded(proto.PageCaptureScreenshotFormatPng, 0)
+	utils.E(err)
+	utils.E(saveFile(saveFileTypeScreenshot, bin, toFile))
+	return bin
+}
+
 // MustRelease is similar to Release
 func (el *Element) MustRelease() {
 	utils.E(el.Release())
@@ -825,6 +1278,13 @@ func (el *Element) MustEval(js string, params ...interface{}) proto.JSON {
 	return res.Value
 }
 
+// MustEvalShadow is similar to EvalShadow
+func (el *Element) MustEvalShadow(js string, params ...interface{}) proto.JSON {
+	res, err := el.EvalShadow(js, params...)
+	utils.E(err)
+	return res.Value
+}
+
 // MustHas is similar to Has
 func (el *Element) MustHas(selector string) bool {
 	has, _, err := el.Has(selector)
@@ -874,6 +1334,13 @@ func (el *Element) MustParent() *Element {
 	return parent
 }
 
+// MustScrollParent is similar to ScrollParent
+func (el *Element) MustScrollParent() *Element {
+	parent, err := el.ScrollParent()
+	utils.E(err)
+	return parent
+}
+
 // MustParents is similar to Parents
 func (el *Element) MustParents(selector string) Elements {
 	list, err := el.Parents(selector)
@@ -944,3 +1411,14 @@ func (r *HijackRouter) MustStop() {
 func (h *Hijack) MustLoadResponse() {
 	utils.E(h.LoadResponse(http.DefaultClient, true))
 }
+
+// MustSet is similar to Set
+func (ev *ExposedValues) MustSet(key string, value interface{}) *ExposedValues {
+	utils.E(ev.Set(key, value))
+	return ev
+}
+
+// MustRemove is similar to Remove
+func (ev *ExposedValues) MustRemove() {
+	utils.E(ev.Remove())
+}
@@ -3,7 +3,11 @@ package rod
 import (
 	"context"
 	"crypto/md5"
+	"encoding/base64"
 	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,24 +32,40 @@ type Page struct {
 
 	browser *Browser
 
-	TargetID  proto.TargetTargetID
+	// TargetID is the id of the target that the page attached to, such as the id in proto.TargetTargetInfo
+	TargetID proto.TargetTargetID
+
+	// SessionID of the cdp session that attached to the target, it's the same as the one returned by CallContext
 	SessionID proto.TargetSessionID
-	FrameID   proto.PageFrameID
+
+	// FrameID of the main frame of the page, or the frame of the iframe
+	FrameID proto.PageFrameID
 
 	// devices
 	Mouse    *Mouse
 	Keyboard *Keyboard
 	Touch    *Touch
 
-	element          *Element                    // iframe only
-	windowObjectID   proto.RuntimeRemoteObjectID // used as the thisObject when eval js
-	jsHelperObjectID proto.RuntimeRemoteObjectID
-	executionIDs     map[proto.PageFrameID]proto.RuntimeExecutionContextID
-	jsContextLock    *sync.Mutex
+	element       *Element // iframe only
+	jsContext     *jsContext
+	executionIDs  map[proto.PageFrameID]proto.RuntimeExecutionContextID
+	jsContextLock *sync.Mutex
+
+	har *harRecorder // shared by all the copies of the page
 
 	event *goob.Observable
 }
 
+// The remote objects of the current js context, it's shared by all the copies of the page and guarded by jsContextLock.
+type jsContext struct {
+	window proto.RuntimeRemoteObjectID // used as the thisObject when eval js
+	helper proto.RuntimeRemoteObjectID
+
+	// the ones of the isolated world, used when EvalOptions.IsolatedWorld is enabled
+	isolatedWindow proto.RuntimeRemoteObjectID
+	isolatedHelper proto.RuntimeRemoteObjectID
+}
+
 // IsIframe tells if it's iframe
 func (p *Page) IsIframe() bool {
 	return p.element != nil
@@ -91,6 +111,48 @@ func (p *Page) SetCookies(cookies []*proto.NetworkCookieParam) error {
 	return err
 }
 
+// ClearCookies removes all the cookies of the browser context the page belongs to.
+func (p *Page) ClearCookies() error {
+	return proto.NetworkClearBrowserCookies{}.Call(p)
+}
+
+// DeleteCookie removes the cookies with the name that match the url.
+// If the url is empty, the url of the current page will be used.
+func (p *Page) DeleteCookie(name, url string) error {
+	if url == "" {
+		info, err := p.Info()
+		if err != nil {
+			return err
+		}
+		url = info.URL
+	}
+
+	return proto.NetworkDeleteCookies{Name: name, URL: url}.Call(p)
+}
+
+// ClearStorage removes the data of the types for the origin of the page, such as the local storage and indexeddb.
+// If the types is empty, all types of the storage will be cleared.
+func (p *Page) ClearStorage(types []proto.StorageStorageType) error {
+	if len(types) == 0 {
+		types = []proto.StorageStorageType{proto.StorageStorageTypeAll}
+	}
+
+	origin, err := p.Eval(`location.origin`)
+	if err != nil {
+		return err
+	}
+
+	list := []string{}
+	for _, t := range types {
+		list = append(list, string(t))
+	}
+
+	return proto.StorageClearDataForOrigin{
+		Origin:       origin.Value.String(),
+		StorageTypes: strings.Join(list, ","),
+	}.Call(p)
+}
+
 // SetExtraHeaders whether to always send extra HTTP headers with the requests from this page.
 func (p *Page) SetExtraHeaders(dict []string) (func(), error) {
 	headers := proto.NetworkHeaders{}
@@ -115,19 +177,91 @@ func (p *Page) SetUserAgent(req *proto.NetworkSetUserAgentOverride) error {
 	return req.Call(p)
 }
 
+// SetBypassCSP enables or disables the Content-Security-Policy of the page.
+// It should be set before the navigation, such as when the scripts injected by EvalOnNewDocument are blocked by the CSP.
+func (p *Page) SetBypassCSP(enabled bool) error {
+	return proto.PageSetBypassCSP{Enabled: enabled}.Call(p)
+}
+
+// SetCacheDisabled toggles ignoring the browser cache for the requests of the page.
+func (p *Page) SetCacheDisabled(disabled bool) error {
+	p.EnableDomain(&proto.NetworkEnable{})
+	return proto.NetworkSetCacheDisabled{CacheDisabled: disabled}.Call(p)
+}
+
+// SetDownloadPath allows the downloads of the page's browser context and saves them to the dir.
+// If the dir is relative, it will be resolved against the current working directory.
+func (p *Page) SetDownloadPath(dir string) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	return proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllow,
+		BrowserContextID: p.browser.BrowserContextID,
+		DownloadPath:     absDir,
+	}.Call(p)
+}
+
 // Navigate to the url. If the url is empty, "about:blank" will be used.
 // It will return immediately after the server responds the http header.
 func (p *Page) Navigate(url string) error {
+	return p.NavigateWithOptions(url, nil)
+}
+
+// NavigateOptions for Page.NavigateWithOptions
+type NavigateOptions struct {
+	// Referrer URL of the navigation
+	Referrer string
+
+	// WaitUntil the lifecycle event of the page is fired, such as proto.PageLifecycleEventNameLoad.
+	// If it's empty, the navigation will return immediately after the server responds the http header.
+	WaitUntil proto.PageLifecycleEventName
+
+	// Timeout of the whole navigation, zero means no timeout
+	Timeout time.Duration
+}
+
+// NavigateWithOptions navigates to the url with the options. If the url is empty, "about:blank" will be used.
+// If opts is nil, it's the same as Page.Navigate.
+func (p *Page) NavigateWithOptions(url string, opts *NavigateOptions) error {
 	if url == "" {
 		url = "about:blank"
 	}
+	if opts == nil {
+		opts = &NavigateOptions{}
+	}
 
-	err := p.StopLoading()
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(p.ctx, opts.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(p.ctx)
+	}
+	defer cancel()
+
+	page := p.Context(ctx)
+
+	err := page.StopLoading()
 	if err != nil {
 		return err
 	}
 
-	res, err := proto.PageNavigate{URL: url}.Call(p)
+	// subscribe before the navigation, or the events may be missed
+	var events <-chan proto.Payload
+	if opts.WaitUntil != "" {
+		_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(page)
+		var stop func()
+		events, stop = page.EventChannel(&proto.PageLifecycleEvent{}, 0)
+		defer func() {
+			stop()
+			_ = proto.PageSetLifecycleEventsEnabled{Enabled: false}.Call(p)
+		}()
+	}
+
+	res, err := proto.PageNavigate{URL: url, Referrer: opts.Referrer}.Call(page)
 	if err != nil {
 		return err
 	}
@@ -137,7 +271,20 @@ func (p *Page) Navigate(url string) error {
 
 	p.FrameID = res.FrameID
 
-	return nil
+	// the same-document navigation has no loader, such as the change of the url hash
+	if events == nil || res.LoaderID == "" {
+		return nil
+	}
+
+	// only the events of the main document count, the iframes also fire them
+	for e := range events {
+		e := e.(*proto.PageLifecycleEvent)
+		if e.FrameID == res.FrameID && e.LoaderID == res.LoaderID && e.Name == opts.WaitUntil {
+			return nil
+		}
+	}
+
+	return ctx.Err()
 }
 
 // NavigateBack history.
@@ -169,7 +316,8 @@ func (p *Page) getWindowID() (proto.BrowserWindowID, error) {
 	return res.WindowID, err
 }
 
-// GetWindow position and size info
+// GetWindow position and size info of the browser window that contains the page,
+// the WindowState of the bounds tells if the window is normal, minimized, maximized, or fullscreen.
 func (p *Page) GetWindow() (*proto.BrowserBounds, error) {
 	id, err := p.getWindowID()
 	if err != nil {
@@ -184,7 +332,8 @@ func (p *Page) GetWindow() (*proto.BrowserBounds, error) {
 	return res.Bounds, nil
 }
 
-// SetWindow location and size
+// SetWindow location and size of the browser window that contains the page.
+// Use Page.SetViewport instead if you only want to emulate the size of the page.
 func (p *Page) SetWindow(bounds *proto.BrowserBounds) error {
 	id, err := p.getWindowID()
 	if err != nil {
@@ -203,6 +352,24 @@ func (p *Page) SetViewport(params *proto.EmulationSetDeviceMetricsOverride) erro
 	return params.Call(p)
 }
 
+// SetTimezone overrides the timezone of the page with the IANA timezone id, such as "Asia/Tokyo".
+// If the tz is empty, the override will be disabled.
+func (p *Page) SetTimezone(tz string) error {
+	return proto.EmulationSetTimezoneOverride{TimezoneID: tz}.Call(p)
+}
+
+// SetLocale overrides the locale of the page with the ICU style locale, such as "de_DE", it affects the
+// formatting of dates and numbers, such as Intl.NumberFormat. If the locale is empty, the override will be disabled.
+func (p *Page) SetLocale(locale string) error {
+	return proto.EmulationSetLocaleOverride{Locale: locale}.Call(p)
+}
+
+// SetJavaScriptEnabled enables or disables the scripts of the page, call it before the navigation.
+// The js of rod, such as Page.Eval, still works when it's disabled.
+func (p *Page) SetJavaScriptEnabled(enabled bool) error {
+	return proto.EmulationSetScriptExecutionDisabled{Value: !enabled}.Call(p)
+}
+
 // Emulate the device, such as iPhone9. If device is devices.Clear, it will clear the override.
 func (p *Page) Emulate(device devices.Device, landscape bool) error {
 	err := p.SetViewport(device.Metrics(landscape))
@@ -279,43 +446,140 @@ func (p *Page) HandleDialog(accept bool, promptText string) func() error {
 	}
 }
 
+// LayoutMetrics returns the content size, layout viewport, and visual viewport of the page
+func (p *Page) LayoutMetrics() (*proto.PageGetLayoutMetricsResult, error) {
+	return proto.PageGetLayoutMetrics{}.Call(p)
+}
+
+// Viewport returns the css size of the layout viewport, the device scale factor,
+// and whether it emulates a mobile device.
+func (p *Page) Viewport() (width, height int, scale float64, mobile bool, err error) {
+	metrics, err := p.LayoutMetrics()
+	if err != nil {
+		return
+	}
+	width = int(metrics.LayoutViewport.ClientWidth)
+	height = int(metrics.LayoutViewport.ClientHeight)
+
+	view := &proto.EmulationSetDeviceMetricsOverride{}
+	if p.LoadState(view) {
+		mobile = view.Mobile
+		scale = view.DeviceScaleFactor
+	}
+
+	if scale == 0 {
+		var res *proto.RuntimeRemoteObject
+		res, err = p.Eval(`() => window.devicePixelRatio`)
+		if err != nil {
+			return
+		}
+		scale = res.Value.Num
+	}
+	return
+}
+
+// AXTreeNode is a node of the accessibility tree with its children linked
+type AXTreeNode struct {
+	*proto.AccessibilityAXNode
+
+	Children []*AXTreeNode
+}
+
+// AXTree returns the root of the full accessibility tree of the page
+func (p *Page) AXTree() (*AXTreeNode, error) {
+	res, err := proto.AccessibilityGetFullAXTree{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[proto.AccessibilityAXNodeID]*AXTreeNode{}
+	for _, n := range res.Nodes {
+		nodes[n.NodeID] = &AXTreeNode{AccessibilityAXNode: n}
+	}
+
+	isChild := map[proto.AccessibilityAXNodeID]bool{}
+	for _, n := range res.Nodes {
+		for _, id := range n.ChildIds {
+			if child, has := nodes[id]; has {
+				nodes[n.NodeID].Children = append(nodes[n.NodeID].Children, child)
+				isChild[id] = true
+			}
+		}
+	}
+
+	for _, n := range res.Nodes {
+		if !isChild[n.NodeID] {
+			return nodes[n.NodeID], nil
+		}
+	}
+
+	return nil, newErr(ErrAXNodeNotFound, res, "the accessibility tree is empty")
+}
+
 // Screenshot options: https://chromedevtools.github.io/devtools-protocol/tot/Page#method-captureScreenshot
 func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]byte, error) {
 	if fullpage {
-		metrics, err := proto.PageGetLayoutMetrics{}.Call(p)
+		restore, err := p.expandViewport()
 		if err != nil {
 			return nil, err
 		}
+		defer restore()
+	}
 
-		oldView := &proto.EmulationSetDeviceMetricsOverride{}
-		set := p.LoadState(oldView)
-		view := *oldView
-		view.Width = int64(metrics.ContentSize.Width)
-		view.Height = int64(metrics.ContentSize.Height)
+	shot, err := req.Call(p)
+	if err != nil {
+		return nil, err
+	}
+	return shot.Data, nil
+}
 
-		err = p.SetViewport(&view)
-		if err != nil {
-			return nil, err
-		}
+// expand the viewport to the size of the whole content, call restore to try to recover the previous viewport
+func (p *Page) expandViewport() (restore func(), err error) {
+	metrics, err := p.LayoutMetrics()
+	if err != nil {
+		return nil, err
+	}
 
-		defer func() { // try to recover the viewport
-			if !set {
-				_ = proto.EmulationClearDeviceMetricsOverride{}.Call(p)
-				return
-			}
+	oldView := &proto.EmulationSetDeviceMetricsOverride{}
+	set := p.LoadState(oldView)
+	view := *oldView
+	view.Width = int64(metrics.ContentSize.Width)
+	view.Height = int64(metrics.ContentSize.Height)
 
-			_ = p.SetViewport(oldView)
-		}()
+	err = p.SetViewport(&view)
+	if err != nil {
+		return nil, err
 	}
 
-	shot, err := req.Call(p)
+	return func() {
+		if !set {
+			_ = proto.EmulationClearDeviceMetricsOverride{}.Call(p)
+			return
+		}
+
+		_ = p.SetViewport(oldView)
+	}, nil
+}
+
+// GetResource returns the content of the resource that the page has loaded, such as a background image or
+// a preloaded asset, the url must be the same as the one that the page requested.
+func (p *Page) GetResource(url string) ([]byte, error) {
+	res, err := proto.PageGetResourceContent{
+		FrameID: p.frameID(),
+		URL:     url,
+	}.Call(p)
 	if err != nil {
 		return nil, err
 	}
-	return shot.Data, nil
+
+	if res.Base64Encoded {
+		return base64.StdEncoding.DecodeString(res.Content)
+	}
+	return []byte(res.Content), nil
 }
 
-// PDF prints page as PDF
+// PDF prints page as PDF. The header and footer templates don't inherit the styles of the page,
+// set the font-size explicitly or the text may be invisible.
 func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
 	req.TransferMode = proto.PagePrintToPDFTransferModeReturnAsStream
 	res, err := req.Call(p)
@@ -326,7 +590,18 @@ func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
 	return NewStreamReader(p, res.Stream), nil
 }
 
-// WaitOpen waits for the next new page opened by the current one
+// CaptureSnapshot of the page as a MHTML string, the CSS, images, and iframes are all inlined into it.
+// It's useful to archive the page into a single file for offline viewing.
+func (p *Page) CaptureSnapshot() (string, error) {
+	res, err := proto.PageCaptureSnapshot{Format: proto.PageCaptureSnapshotFormatMhtml}.Call(p)
+	if err != nil {
+		return "", err
+	}
+	return res.Data, nil
+}
+
+// WaitOpen waits for the next new page opened by the current one, such as the popup opened by window.open.
+// Only the targets of type "page" will be matched, the workers or iframes created by the current page are ignored.
 func (p *Page) WaitOpen() func() (*Page, error) {
 	b := p.browser.Context(p.ctx)
 	var targetID proto.TargetTargetID
@@ -334,7 +609,7 @@ func (p *Page) WaitOpen() func() (*Page, error) {
 	ctx, cancel := context.WithCancel(p.ctx)
 	wait := b.Context(ctx).EachEvent(func(e *proto.TargetTargetCreated) bool {
 		targetID = e.TargetInfo.TargetID
-		return e.TargetInfo.OpenerID == p.TargetID
+		return e.TargetInfo.OpenerID == p.TargetID && e.TargetInfo.Type == proto.TargetTargetInfoTypePage
 	})
 
 	return func() (*Page, error) {
@@ -374,11 +649,37 @@ func (p *Page) EachEvent(callbacks ...interface{}) (wait func()) {
 	return p.browser.eachEvent(p.ctx, p.SessionID, callbacks...)
 }
 
+// OnPageError calls fn with each uncaught exception thrown by the js of the page, call stop to unsubscribe.
+func (p *Page) OnPageError(fn func(e *proto.RuntimeExceptionThrown)) (stop func()) {
+	ctx, cancel := context.WithCancel(p.ctx)
+
+	go p.Context(ctx).EachEvent(fn)()
+
+	return cancel
+}
+
+// OnLoad calls fn each time the main frame fires the load event, call stop to unsubscribe.
+func (p *Page) OnLoad(fn func(p *Page)) (stop func()) {
+	ctx, cancel := context.WithCancel(p.ctx)
+
+	go p.Context(ctx).EachEvent(func(e *proto.PageLoadEventFired) {
+		fn(p)
+	})()
+
+	return cancel
+}
+
 // WaitEvent waits for the next event for one time. It will also load the data into the event object.
 func (p *Page) WaitEvent(e proto.Payload) (wait func()) {
 	return p.browser.waitEvent(p.ctx, p.SessionID, e)
 }
 
+// EventChannel returns a buffered channel that receives the events of the same type as e,
+// call stop to unsubscribe and close the channel.
+func (p *Page) EventChannel(e proto.Payload, size int) (events <-chan proto.Payload, stop func()) {
+	return p.browser.eventChannel(p.ctx, p.SessionID, e, size)
+}
+
 // WaitNavigation wait for a page lifecycle event when navigating.
 // Usually you will wait for proto.PageLifecycleEventNameNetworkAlmostIdle
 func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
@@ -394,6 +695,36 @@ func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
 	}
 }
 
+// SetLifecycleEventsEnabled controls whether the page fires the proto.PageLifecycleEvent
+func (p *Page) SetLifecycleEventsEnabled(enabled bool) error {
+	return proto.PageSetLifecycleEventsEnabled{Enabled: enabled}.Call(p)
+}
+
+// WaitLifecycle returns a wait function that waits until the main frame fires the lifecycle event, the events
+// of the iframes are ignored. Call it before the navigation, or the event may be missed.
+func (p *Page) WaitLifecycle(name proto.PageLifecycleEventName) (wait func(), err error) {
+	err = p.SetLifecycleEventsEnabled(true)
+	if err != nil {
+		return nil, err
+	}
+
+	frameID := p.frameID()
+	w := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
+		return e.FrameID == frameID && e.Name == name
+	})
+
+	return func() {
+		w()
+		_ = p.SetLifecycleEventsEnabled(false)
+	}, nil
+}
+
+// WaitDOMStable is similar to WaitLifecycle with proto.PageLifecycleEventNameDOMContentLoaded,
+// the DOM is parsed, but the resources such as images may still be loading.
+func (p *Page) WaitDOMStable() (wait func(), err error) {
+	return p.WaitLifecycle(proto.PageLifecycleEventNameDOMContentLoaded)
+}
+
 // WaitRequestIdle returns a wait function that waits until no request for d duration.
 // Be careful, d is not the max wait timeout, it's the least idle time.
 // If you want to set a timeout you can use the "Page.Timeout" function.
@@ -449,6 +780,67 @@ func (p *Page) WaitRequestIdle(d time.Duration, includes, excludes []string) fun
 	}
 }
 
+// WaitRequest returns a wait function that waits until the first request whose url matches the pattern finishes,
+// then returns its response and body. Use Page.Timeout to limit the waiting time.
+func (p *Page) WaitRequest(pattern string) func() (*proto.NetworkResponseReceived, []byte, error) {
+	filter := genRegFilter([]string{pattern}, nil)
+
+	var id proto.NetworkRequestID
+	var res *proto.NetworkResponseReceived
+	var body []byte
+	var err error
+	done := false
+
+	// the body must be fetched before the wait ends, the Network domain may be disabled after it
+	wait := p.EachEvent(func(e *proto.NetworkRequestWillBeSent) {
+		if id == "" && filter(e.Request.URL) {
+			id = e.RequestID
+		}
+	}, func(e *proto.NetworkResponseReceived) {
+		if id != "" && e.RequestID == id {
+			res = e
+		}
+	}, func(e *proto.NetworkLoadingFinished) bool {
+		if id == "" || e.RequestID != id {
+			return false
+		}
+		done = true
+		body, err = p.getResponseBody(id)
+		return true
+	}, func(e *proto.NetworkLoadingFailed) bool {
+		if id == "" || e.RequestID != id {
+			return false
+		}
+		done = true
+		err = newErr(ErrRequestFailed, e, e.ErrorText)
+		return true
+	})
+
+	return func() (*proto.NetworkResponseReceived, []byte, error) {
+		wait()
+
+		if !done {
+			return nil, nil, p.ctx.Err()
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return res, body, nil
+	}
+}
+
+func (p *Page) getResponseBody(id proto.NetworkRequestID) ([]byte, error) {
+	res, err := proto.NetworkGetResponseBody{RequestID: id}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Base64Encoded {
+		return base64.StdEncoding.DecodeString(res.Body)
+	}
+	return []byte(res.Body), nil
+}
+
 // WaitIdle waits until the next window.requestIdleCallback is called.
 func (p *Page) WaitIdle(timeout time.Duration) (err error) {
 	_, err = p.EvalWithOptions(jsHelper(js.WaitIdle, JSArgs{timeout.Seconds()}))
@@ -493,6 +885,136 @@ func (p *Page) EvalOnNewDocument(js string) (proto.PageScriptIdentifier, error)
 	return res.Identifier, nil
 }
 
+// StealthInit injects the well-known patches to make the headless browser look like a normal one, it's best-effort.
+// It should be called before the navigation.
+func (p *Page) StealthInit() error {
+	_, err := p.EvalOnNewDocument(assets.Stealth)
+	return err
+}
+
+// ExposedValues is the cached result store created by Page.ExposeValues
+type ExposedValues struct {
+	page    *Page
+	name    string
+	binding string
+	stop    func()
+
+	lock   sync.Mutex
+	values map[string]interface{}
+	script proto.PageScriptIdentifier
+}
+
+// ExposeValues exposes a function to the page's window object that synchronously returns the value of the key,
+// each document caches the values, the missing keys are requested via Runtime.addBinding. Call it before navigation.
+func (p *Page) ExposeValues(name string, values map[string]interface{}) (*ExposedValues, error) {
+	ev := &ExposedValues{
+		page:    p,
+		name:    name,
+		binding: "rod_expose_values_" + name,
+		values:  map[string]interface{}{},
+	}
+	for k, v := range values {
+		ev.values[k] = v
+	}
+
+	err := proto.RuntimeAddBinding{Name: ev.binding}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ev.register()
+	if err != nil {
+		_ = proto.RuntimeRemoveBinding{Name: ev.binding}.Call(p)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	ev.stop = cancel
+
+	go p.Context(ctx).EachEvent(func(e *proto.RuntimeBindingCalled) {
+		if e.Name == ev.binding {
+			ev.fill(e.ExecutionContextID, e.Payload)
+		}
+	})()
+
+	return ev, nil
+}
+
+// Set the value of the key, it updates the documents of the page and the new documents.
+func (ev *ExposedValues) Set(key string, value interface{}) error {
+	ev.lock.Lock()
+	defer ev.lock.Unlock()
+
+	ev.values[key] = value
+
+	err := proto.PageRemoveScriptToEvaluateOnNewDocument{Identifier: ev.script}.Call(ev.page)
+	if err != nil {
+		return err
+	}
+	err = ev.register()
+	if err != nil {
+		return err
+	}
+
+	_, err = ev.page.Eval(`(name, key, value) => {
+		const fn = window[name]
+		if (fn) fn.cache[key] = value
+	}`, ev.name, key, value)
+	return err
+}
+
+// Remove stops exposing the function to the new documents. The documents already loaded keep their cache.
+func (ev *ExposedValues) Remove() error {
+	ev.stop()
+
+	err := proto.RuntimeRemoveBinding{Name: ev.binding}.Call(ev.page)
+	if err != nil {
+		return err
+	}
+
+	ev.lock.Lock()
+	defer ev.lock.Unlock()
+
+	return proto.PageRemoveScriptToEvaluateOnNewDocument{Identifier: ev.script}.Call(ev.page)
+}
+
+// register the script that creates the cache of the current values for each new document
+func (ev *ExposedValues) register() error {
+	js := fmt.Sprintf(`;(() => {
+		const name = %s, binding = %s
+		const fn = (key) => {
+			if (!(key in fn.cache) && window[binding]) window[binding](String(key))
+			return fn.cache[key]
+		}
+		fn.cache = %s
+		window[name] = fn
+	})()`, utils.MustToJSON(ev.name), utils.MustToJSON(ev.binding), utils.MustToJSON(ev.values))
+
+	id, err := ev.page.EvalOnNewDocument(js)
+	if err != nil {
+		return err
+	}
+	ev.script = id
+	return nil
+}
+
+// fill the cache of the document that missed the key, keys unknown to Go are ignored
+func (ev *ExposedValues) fill(ctxID proto.RuntimeExecutionContextID, key string) {
+	ev.lock.Lock()
+	value, has := ev.values[key]
+	ev.lock.Unlock()
+
+	if !has {
+		return
+	}
+
+	_, _ = proto.RuntimeEvaluate{
+		Expression: fmt.Sprintf(`window[%s].cache[%s] = %s`,
+			utils.MustToJSON(ev.name), utils.MustToJSON(key), utils.MustToJSON(value)),
+		ContextID: ctxID,
+	}.Call(ev.page)
+}
+
 // Expose function to the page's window object. Must bind before navigate to the page. Bindings survive reloads.
 // Binding function takes exactly one argument, this argument should be string.
 func (p *Page) Expose(name string) (callback chan string, stop func(), err error) {
@@ -527,6 +1049,30 @@ func (p *Page) Eval(js string, jsArgs ...interface{}) (*proto.RuntimeRemoteObjec
 	return p.EvalWithOptions(NewEvalOptions(js, jsArgs))
 }
 
+// EvalOnSelector evaluates js on the first element that matches the css selector, the "this" of the js
+// will be the element. It won't wait for the element, if nothing matches ErrElementNotFound will be returned.
+func (p *Page) EvalOnSelector(selector, js string, params ...interface{}) (*proto.RuntimeRemoteObject, error) {
+	el, err := p.Sleeper(nil).Element(selector)
+	if err != nil {
+		return nil, err
+	}
+	return el.Eval(js, params...)
+}
+
+// EvalOnSelectorAll evaluates js with the array of the elements that match the css selector as the first argument.
+func (p *Page) EvalOnSelectorAll(selector, js string, params ...interface{}) (*proto.RuntimeRemoteObject, error) {
+	list, err := p.EvalWithOptions(NewEvalOptions(
+		`s => Array.from(document.querySelectorAll(s))`,
+		JSArgs{selector},
+	).ByObject())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = p.Release(list.ObjectID) }()
+
+	return p.Eval(js, append(JSArgs{list.ObjectID}, params...)...)
+}
+
 // EvalWithOptions evaluates js on the page.
 func (p *Page) EvalWithOptions(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
 	backoff := utils.BackoffSleeper(30*time.Millisecond, 3*time.Second, nil)
@@ -536,9 +1082,14 @@ func (p *Page) EvalWithOptions(opts *EvalOptions) (*proto.RuntimeRemoteObject, e
 
 	// js context will be invalid if a frame is reloaded or not ready, then the isNilContextErr
 	// will be true, then we retry the eval again.
+	initJS := p.initJS
+	if opts.IsolatedWorld {
+		initJS = p.initIsolatedJS
+	}
+
 	err = utils.Retry(p.ctx, backoff, func() (bool, error) {
-		if p.getWindowObjectID() == "" || opts.ThisID == "" {
-			err := p.initJS(false)
+		if p.getWindowObjectID(opts.IsolatedWorld) == "" || opts.ThisID == "" {
+			err := initJS(false)
 			if err != nil {
 				if isNilContextErr(err) {
 					return false, nil
@@ -547,7 +1098,7 @@ func (p *Page) EvalWithOptions(opts *EvalOptions) (*proto.RuntimeRemoteObject, e
 			}
 		}
 		if opts.ThisID == "" {
-			objectID = p.getWindowObjectID()
+			objectID = p.getWindowObjectID(opts.IsolatedWorld)
 		}
 
 		// construct arguments
@@ -555,7 +1106,7 @@ func (p *Page) EvalWithOptions(opts *EvalOptions) (*proto.RuntimeRemoteObject, e
 		for _, arg := range opts.JSArgs {
 			if id, ok := arg.(proto.RuntimeRemoteObjectID); ok { // remote object
 				if id == jsHelperID { // if it's a rod js helper object
-					id = p.getJSHelperObjectID()
+					id = p.getJSHelperObjectID(opts.IsolatedWorld)
 				}
 				args = append(args, &proto.RuntimeCallArgument{Value: proto.NewJSON(nil), ObjectID: id})
 			} else { // plain json data
@@ -563,16 +1114,22 @@ func (p *Page) EvalWithOptions(opts *EvalOptions) (*proto.RuntimeRemoteObject, e
 			}
 		}
 
+		inFlight := p.ctx.Err() == nil
 		res, err = proto.RuntimeCallFunctionOn{
 			ObjectID:            objectID,
 			AwaitPromise:        true,
 			ReturnByValue:       opts.ByValue,
+			GeneratePreview:     opts.GeneratePreview,
 			UserGesture:         opts.UserGesture,
 			FunctionDeclaration: formatToJSFunc(opts.JS),
 			Arguments:           args,
 		}.Call(p)
+		if err != nil && opts.TerminateOnTimeout && inFlight && p.ctx.Err() != nil {
+			p.terminateBlockingJS()
+			return true, err
+		}
 		if opts.ThisID == "" && isNilContextErr(err) {
-			_ = p.initJS(true)
+			_ = initJS(true)
 			return false, nil
 		}
 
@@ -584,13 +1141,24 @@ func (p *Page) EvalWithOptions(opts *EvalOptions) (*proto.RuntimeRemoteObject, e
 	}
 
 	if res.ExceptionDetails != nil {
-		exp := res.ExceptionDetails.Exception
-		return nil, newErr(ErrEval, exp, exp.Description+" "+exp.Value.String())
+		return nil, newErr(ErrEval, res.ExceptionDetails.Exception, evalErrMsg(res.ExceptionDetails))
 	}
 
 	return res.Result, nil
 }
 
+// the message contains the text, location, and the description of the exception, the description usually
+// includes the js stack trace
+func evalErrMsg(details *proto.RuntimeExceptionDetails) string {
+	msg := fmt.Sprintf("%s at line %d, column %d", details.Text, details.LineNumber, details.ColumnNumber)
+
+	if exp := details.Exception; exp != nil {
+		msg += ": " + strings.TrimSpace(exp.Description+" "+exp.Value.String())
+	}
+
+	return msg
+}
+
 // Wait js function until it returns true
 func (p *Page) Wait(thisID proto.RuntimeRemoteObjectID, js string, params JSArgs) error {
 	removeTrace := func() {}
@@ -601,7 +1169,7 @@ func (p *Page) Wait(thisID proto.RuntimeRemoteObjectID, js string, params JSArgs
 		removeTrace()
 		removeTrace = remove
 
-		res, err := p.EvalWithOptions(NewEvalOptions(js, params).This(thisID))
+		res, err := p.EvalWithOptions(NewEvalOptions(js, params).This(thisID).WithTerminate())
 		if err != nil {
 			return true, err
 		}
@@ -689,6 +1257,23 @@ func (p *Page) CallContext() (context.Context, proto.Client, string) {
 	return p.ctx, p.browser, string(p.SessionID)
 }
 
+// terminate the running js if the main thread of the page is still blocked, it's checked by a probe eval,
+// if the probe doesn't respond in time the main thread is blocked.
+func (p *Page) terminateBlockingJS() {
+	probe, cancel := context.WithTimeout(p.browser.ctx, 300*time.Millisecond)
+	defer cancel()
+
+	_, err := proto.RuntimeEvaluate{Expression: "0"}.Call(p.Context(probe))
+	if err == nil || probe.Err() == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(p.browser.ctx, time.Second)
+	defer cancel()
+
+	_ = proto.RuntimeTerminateExecution{}.Call(p.Context(ctx))
+}
+
 func (p *Page) initSession() error {
 	obj, err := proto.TargetAttachToTarget{
 		TargetID: p.TargetID,
@@ -707,6 +1292,9 @@ func (p *Page) initSession() error {
 	// even after we re-enable it again we can't query the ids any more.
 	p.EnableDomain(&proto.DOMEnable{})
 
+	// So that we can tell the calls fail because of the crash of the page, check ErrPageCrashed
+	p.EnableDomain(&proto.InspectorEnable{})
+
 	return nil
 }
 
@@ -719,16 +1307,65 @@ func (p *Page) initJS(force bool) error {
 	p.jsContextLock.Lock()
 	defer p.jsContextLock.Unlock()
 
-	if !force && p.windowObjectID != "" {
+	if !force && p.jsContext.window != "" {
+		return nil
+	}
+
+	window, helper, err := p.loadJSHelper(contextID)
+	if err != nil {
+		return err
+	}
+
+	p.jsContext.window = window
+	p.jsContext.helper = helper
+
+	return nil
+}
+
+// Similar to initJS, but the js context is in a world isolated from the page's js globals.
+func (p *Page) initIsolatedJS(force bool) error {
+	p.jsContextLock.Lock()
+	defer p.jsContextLock.Unlock()
+
+	if !force && p.jsContext.isolatedWindow != "" {
 		return nil
 	}
 
+	world, err := proto.PageCreateIsolatedWorld{
+		FrameID:   p.frameID(),
+		WorldName: "rod_isolated_world",
+	}.Call(p)
+	if err != nil {
+		return err
+	}
+
+	window, helper, err := p.loadJSHelper(world.ExecutionContextID)
+	if err != nil {
+		return err
+	}
+
+	p.jsContext.isolatedWindow = window
+	p.jsContext.isolatedHelper = helper
+
+	return nil
+}
+
+// the id of the frame that the page represents, it works even if the page is never navigated by Page.Navigate
+func (p *Page) frameID() proto.PageFrameID {
+	if !p.IsIframe() {
+		return proto.PageFrameID(p.TargetID) // the id of the main frame is the same as the target's
+	}
+	return p.FrameID
+}
+
+// load the window object and the rod js helper of the execution context
+func (p *Page) loadJSHelper(contextID proto.RuntimeExecutionContextID) (proto.RuntimeRemoteObjectID, proto.RuntimeRemoteObjectID, error) {
 	window, err := proto.RuntimeEvaluate{
 		Expression: "window",
 		ContextID:  contextID,
 	}.Call(p)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	helper, err := proto.RuntimeCallFunctionOn{
@@ -736,13 +1373,10 @@ func (p *Page) initJS(force bool) error {
 		FunctionDeclaration: assets.Helper,
 	}.Call(p)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	p.windowObjectID = window.Result.ObjectID
-	p.jsHelperObjectID = helper.Result.ObjectID
-
-	return nil
+	return window.Result.ObjectID, helper.Result.ObjectID, nil
 }
 
 // We use this function to make sure every frame(page, iframe) will only have one IsolatedWorld.
@@ -778,16 +1412,22 @@ func (p *Page) getExecutionID(force bool) (proto.RuntimeExecutionContextID, erro
 	return world.ExecutionContextID, nil
 }
 
-func (p *Page) getWindowObjectID() proto.RuntimeRemoteObjectID {
+func (p *Page) getWindowObjectID(isolated bool) proto.RuntimeRemoteObjectID {
 	p.jsContextLock.Lock()
 	defer p.jsContextLock.Unlock()
-	return p.windowObjectID
+	if isolated {
+		return p.jsContext.isolatedWindow
+	}
+	return p.jsContext.window
 }
 
-func (p *Page) getJSHelperObjectID() proto.RuntimeRemoteObjectID {
+func (p *Page) getJSHelperObjectID(isolated bool) proto.RuntimeRemoteObjectID {
 	p.jsContextLock.Lock()
 	defer p.jsContextLock.Unlock()
-	return p.jsHelperObjectID
+	if isolated {
+		return p.jsContext.isolatedHelper
+	}
+	return p.jsContext.helper
 }
 
 func (p *Page) enableNodeQuery() {
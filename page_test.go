@@ -3,6 +3,8 @@ package rod_test
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"image/png"
 	"io/ioutil"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -27,6 +30,17 @@ func (s *S) TestGetPageURL() {
 	s.Regexp(`/fixtures/click-iframe.html\z`, s.page.MustInfo().URL)
 }
 
+func (s *S) TestPageTargetAndSessionID() {
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	s.Equal(p.TargetID, p.MustInfo().TargetID)
+
+	_, _, sessionID := p.CallContext()
+	s.NotEmpty(p.SessionID)
+	s.Equal(string(p.SessionID), sessionID)
+}
+
 func (s *S) TestSetCookies() {
 	url, _, close := utils.Serve("")
 	defer close()
@@ -60,6 +74,73 @@ func (s *S) TestSetCookies() {
 	})
 }
 
+func (s *S) TestPageClearAndDeleteCookies() {
+	url, _, close := utils.Serve("")
+	defer close()
+
+	page := s.browser.MustPage("")
+	defer page.MustClose()
+
+	page.MustSetCookies(&proto.NetworkCookieParam{
+		Name:  "a",
+		Value: "1",
+		URL:   url,
+	}, &proto.NetworkCookieParam{
+		Name:  "b",
+		Value: "2",
+		URL:   url,
+	}).MustNavigate(url)
+
+	page.MustDeleteCookie("a", "")
+	cookies := page.MustCookies()
+	s.Len(cookies, 1)
+	s.Equal("b", cookies[0].Name)
+
+	page.MustClearCookies()
+	s.Len(page.MustCookies(), 0)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.TargetGetTargetInfo{})
+		page.MustDeleteCookie("b", "")
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.NetworkDeleteCookies{})
+		page.MustDeleteCookie("b", url)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.NetworkClearBrowserCookies{})
+		page.MustClearCookies()
+	})
+}
+
+func (s *S) TestPageClearStorage() {
+	url, _, close := utils.Serve("")
+	defer close()
+
+	page := s.browser.MustPage(url).MustWaitLoad()
+	defer page.MustClose()
+
+	get := func() string {
+		return page.MustEval(`localStorage.getItem('a') || ''`).String()
+	}
+
+	page.MustEval(`localStorage.setItem('a', '1')`)
+	s.Equal("1", get())
+	page.MustClearStorage(proto.StorageStorageTypeCookies)
+	s.Equal("1", get())
+	page.MustClearStorage()
+	s.Equal("", get())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustClearStorage()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.StorageClearDataForOrigin{})
+		page.MustClearStorage()
+	})
+}
+
 func (s *S) TestSetExtraHeaders() {
 	url, mux, close := utils.Serve("")
 	defer close()
@@ -85,6 +166,169 @@ func (s *S) TestSetExtraHeaders() {
 	s.Equal("2", out2)
 }
 
+func (s *S) TestPageCrashed() {
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	wait := p.WaitEvent(&proto.InspectorTargetCrashed{})
+	_ = p.Navigate("chrome://crash")
+	wait()
+
+	_, err := p.Eval(`1`)
+	s.ErrorIs(err, rod.ErrPageCrashed)
+
+	wait = p.WaitEvent(&proto.InspectorTargetReloadedAfterCrash{})
+	p.MustReload()
+	wait()
+
+	s.Equal(1, p.MustEval(`1`).Int())
+}
+
+func (s *S) TestPageSetBypassCSP() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Security-Policy", "script-src 'none'")
+		httpHTML(`<html><script>document.title = 'ok'</script></html>`)(w, r)
+	})
+
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	p.MustNavigate(url).MustWaitLoad()
+	s.Equal("", p.MustEval(`document.title`).String())
+
+	p.MustSetBypassCSP(true).MustNavigate(url).MustWaitLoad()
+	s.Equal("ok", p.MustEval(`document.title`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageSetBypassCSP{})
+		p.MustSetBypassCSP(false)
+	})
+}
+
+func (s *S) TestPageSetTimezone() {
+	p := s.browser.MustPage(srcFile("fixtures/click.html"))
+	defer p.MustClose()
+
+	tz := func() string {
+		return p.MustEval(`Intl.DateTimeFormat().resolvedOptions().timeZone`).String()
+	}
+
+	p.MustSetTimezone("Asia/Tokyo")
+	s.Equal("Asia/Tokyo", tz())
+	s.Equal(-540, p.MustEval(`new Date(0).getTimezoneOffset()`).Int())
+
+	err := p.SetTimezone("Not/Exists")
+	s.Contains(err.Error(), "Invalid timezone")
+	s.Equal("Asia/Tokyo", tz())
+
+	p.MustSetTimezone("")
+}
+
+func (s *S) TestPageSetLocale() {
+	p := s.browser.MustPage(srcFile("fixtures/click.html"))
+	defer p.MustClose()
+
+	p.MustSetLocale("de_DE").MustSetTimezone("Europe/Berlin")
+	s.Equal("de-DE", p.MustEval(`Intl.NumberFormat().resolvedOptions().locale`).String())
+	s.Equal("1.234,5", p.MustEval(`(1234.5).toLocaleString()`).String())
+	s.Equal("1.1.1970, 01:00:00", p.MustEval(`new Date(0).toLocaleString()`).String())
+
+	p.MustSetLocale("")
+}
+
+func (s *S) TestPageSetJavaScriptEnabled() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	mux.HandleFunc("/", httpHTML(`<html><body>
+		<noscript>no js</noscript>
+		<script>document.body.setAttribute('js', 'on')</script>
+	</body></html>`))
+
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	p.MustSetJavaScriptEnabled(false).MustNavigate(url).MustWaitLoad()
+	s.False(p.MustHas("body[js=on]"))
+	s.True(p.MustElement("noscript").MustVisible())
+
+	p.MustSetJavaScriptEnabled(true).MustReload().MustWaitLoad()
+	s.True(p.MustHas("body[js=on]"))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.EmulationSetScriptExecutionDisabled{})
+		p.MustSetJavaScriptEnabled(false)
+	})
+}
+
+func (s *S) TestPageSetCacheDisabled() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	count := int64(0)
+	mux.HandleFunc("/", httpHTML(`<html><script src="/a.js"></script></html>`))
+	mux.HandleFunc("/a.js", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&count, 1)
+		w.Header().Set("Cache-Control", "max-age=3600")
+		w.Header().Set("Content-Type", "application/javascript")
+	})
+
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	p.MustNavigate(url).MustWaitLoad()
+	p.MustNavigate(url).MustWaitLoad()
+	s.EqualValues(1, atomic.LoadInt64(&count))
+
+	p.MustSetCacheDisabled(true)
+	p.MustNavigate(url).MustWaitLoad()
+	s.EqualValues(2, atomic.LoadInt64(&count))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.NetworkSetCacheDisabled{})
+		p.MustSetCacheDisabled(false)
+	})
+}
+
+func (s *S) TestPageSetDownloadPath() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	content := "test content"
+
+	mux.HandleFunc("/d", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Disposition", "attachment; filename=file.txt")
+		utils.E(w.Write([]byte(content)))
+	})
+	mux.HandleFunc("/", httpHTML(`<html><a href="/d">click</a></html>`))
+
+	dir := filepath.Join("tmp", "download", utils.RandString(8))
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	p := s.browser.MustPage(url).MustSetDownloadPath(dir)
+	defer p.MustClose()
+
+	wait := p.WaitEvent(&proto.PageDownloadWillBegin{})
+	p.MustElement("a").MustClick()
+	wait()
+
+	file := filepath.Join(dir, "file.txt")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.Nil(utils.Retry(ctx, utils.BackoffSleeper(30*time.Millisecond, 300*time.Millisecond, nil), func() (bool, error) {
+		data, err := ioutil.ReadFile(file)
+		return err == nil && string(data) == content, nil
+	}))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.BrowserSetDownloadBehavior{})
+		p.MustSetDownloadPath(dir)
+	})
+}
+
 func (s *S) TestSetUserAgent() {
 	url, mux, close := utils.Serve("")
 	defer close()
@@ -189,6 +433,35 @@ func (s *S) TestSetViewport() {
 	s.NotEqual(int64(317), res.Get("0").Int())
 }
 
+func (s *S) TestPageViewport() {
+	page := s.browser.MustPage(srcFile("fixtures/click.html"))
+	defer page.MustClose()
+
+	page.MustSetViewport(317, 419, 0, false)
+	width, height, scale, mobile := page.MustViewport()
+	s.Equal(317, width)
+	s.Equal(419, height)
+	s.Equal(page.MustEval(`() => window.devicePixelRatio`).Num, scale)
+	s.False(mobile)
+
+	page.MustEmulate(devices.IPhone6or7or8Plus)
+	width, height, scale, mobile = page.MustViewport()
+	s.Equal(980, width)
+	s.Equal(1743, height)
+	s.Equal(3.0, scale)
+	s.True(mobile)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageGetLayoutMetrics{})
+		page.MustViewport()
+	})
+	s.Panics(func() {
+		page.MustSetViewport(317, 419, 0, false)
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustViewport()
+	})
+}
+
 func (s *S) TestEmulateDevice() {
 	page := s.browser.MustPage(srcFile("fixtures/click.html"))
 	defer page.MustClose()
@@ -280,20 +553,116 @@ func (s *S) TestPageEval() {
 	`, 1, 2).Int())
 	s.EqualValues(1, page.MustEval(`a => 1`).Int())
 	s.EqualValues(1, page.MustEval(`function() { return 1 }`).Int())
+	s.EqualValues(1, page.MustEval(`async () => 1`).Int())
+	s.EqualValues(1, page.MustEval(`async a => 1`).Int())
+	s.EqualValues(1, page.MustEval(`async function() { return 1 }`).Int())
+	s.EqualValues(1, page.MustEval(`async function() { await new Promise(r => setTimeout(r, 10)); return 1 }`).Int())
 	s.EqualValues(1, page.MustEval(`((1))`).Int())
 	s.NotEqualValues(1, page.MustEval(`a = () => 1`).Int())
 	s.NotEqualValues(1, page.MustEval(`a = function() { return 1 }`))
 	s.NotEqualValues(1, page.MustEval(`/* ) */`))
 }
 
+func (s *S) TestPageEvalOnSelector() {
+	page := s.page.MustNavigate(srcFile("fixtures/click.html"))
+
+	s.Equal("click me!", page.MustEvalOnSelector("button", `s => this.innerText + s`, "!").String())
+
+	_, err := page.EvalOnSelector("not-exists", `() => 1`)
+	s.ErrorIs(err, rod.ErrElementNotFound)
+}
+
+func (s *S) TestPageEvalOnSelectorAll() {
+	page := s.page.MustNavigate(srcFile("fixtures/input.html"))
+
+	s.EqualValues(4, page.MustEvalOnSelectorAll("option", `list => list.length`).Int())
+	s.Equal("A,B,C,CC", page.MustEvalOnSelectorAll(
+		"option", `(list, sep) => list.map(el => el.innerText).join(sep)`, ",",
+	).String())
+	s.EqualValues(0, page.MustEvalOnSelectorAll("not-exists", `list => list.length`).Int())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustEvalOnSelectorAll("option", `list => list.length`)
+	})
+}
+
+func (s *S) TestPageConcurrentEval() {
+	p := s.browser.MustPage(srcFile("fixtures/click.html")).MustWaitLoad()
+	defer p.MustClose()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Equal("click me", p.Timeout(time.Minute).MustElement("button").MustText())
+		}()
+	}
+	wg.Wait()
+
+	// the js context re-created by a copy of the page should be shared with the page
+	p.MustReload()
+	p.Timeout(time.Minute).MustWaitLoad()
+	s.Equal("click me", p.MustElement("button").MustText())
+}
+
+func (s *S) TestPageEvalWithPreview() {
+	page := s.page.MustNavigate(srcFile("fixtures/click.html"))
+
+	res, err := page.EvalWithOptions(rod.NewEvalOptions(`({ a: 1 })`, nil).ByObject().WithPreview())
+	utils.E(err)
+	s.Equal("a", res.Preview.Properties[0].Name)
+	s.Equal("1", res.Preview.Properties[0].Value)
+
+	res, err = page.EvalWithOptions(rod.NewEvalOptions(`({ a: 1 })`, nil).ByObject())
+	utils.E(err)
+	s.Nil(res.Preview)
+}
+
+func (s *S) TestPageEvalIsolatedWorld() {
+	page := s.browser.MustPage(srcFile("fixtures/click.html")).MustWaitLoad()
+	defer page.MustClose()
+
+	page.MustEval(`() => { window.a = 1; Array.from = () => 'hacked' }`)
+	s.Equal("hacked", page.MustEval(`() => Array.from([1, 2])`).Str)
+
+	isolated := func(js string) *proto.RuntimeRemoteObject {
+		res, err := page.EvalWithOptions(rod.NewEvalOptions(js, nil).InIsolatedWorld())
+		utils.E(err)
+		return res
+	}
+
+	s.EqualValues(2, isolated(`() => Array.from([1, 2]).length`).Value.Int())
+	s.Equal("undefined", isolated(`() => typeof a`).Value.Str)
+	s.Equal("click me", isolated(`() => document.querySelector('button').innerText`).Value.Str)
+
+	// the isolated world should be re-created after the reload
+	page.MustReload().MustWaitLoad()
+	s.EqualValues(2, isolated(`() => Array.from([1, 2]).length`).Value.Int())
+
+	page2 := s.browser.MustPage("")
+	defer page2.MustClose()
+	s.mc.stubErr(1, proto.PageCreateIsolatedWorld{})
+	_, err := page2.EvalWithOptions(rod.NewEvalOptions(`1`, nil).InIsolatedWorld())
+	s.Error(err)
+}
+
 func (s *S) TestPageEvalNilContext() {
 	page := s.browser.MustPage(srcFile("fixtures/click.html"))
 	defer page.MustClose()
 
 	s.mc.stub(1, proto.RuntimeEvaluate{}, func(func() ([]byte, error)) ([]byte, error) {
-		return nil, &cdp.Error{Code: -32000}
+		return nil, &cdp.Error{Code: -32000, Message: "Cannot find context with specified id"}
 	})
 	s.EqualValues(1, page.MustEval(`1`).Int())
+
+	// fatal errors should fail immediately rather than retry until the timeout
+	s.mc.stub(1, proto.RuntimeCallFunctionOn{}, func(func() ([]byte, error)) ([]byte, error) {
+		return nil, &cdp.Error{Code: -32000, Message: "Argument should belong to the same JavaScript world as target object"}
+	})
+	_, err := page.Timeout(time.Minute).Eval(`1`)
+	s.EqualError(err, `{"code":-32000,"message":"Argument should belong to the same JavaScript world as target object","data":""}`)
 }
 
 func (s *S) TestPageExposeJSHelper() {
@@ -305,6 +674,44 @@ func (s *S) TestPageExposeJSHelper() {
 	s.Equal("object", page.MustEval("typeof(rod)").Str)
 }
 
+func (s *S) TestPageOnPageError() {
+	page := s.browser.MustPage(srcFile("fixtures/click.html")).MustWaitLoad()
+	defer page.MustClose()
+
+	errs := make(chan *proto.RuntimeExceptionThrown, 1)
+	stop := page.OnPageError(func(e *proto.RuntimeExceptionThrown) {
+		errs <- e
+	})
+
+	page.MustEval(`() => setTimeout(() => { throw new Error('boom') })`)
+	e := <-errs
+	s.Contains(e.ExceptionDetails.Text, "Uncaught")
+	s.Contains(e.ExceptionDetails.Exception.Description, "Error: boom")
+
+	stop()
+}
+
+func (s *S) TestPageOnLoad() {
+	page := s.browser.MustPage("")
+	defer page.MustClose()
+
+	loads := make(chan string, 2)
+	stop := page.OnLoad(func(p *rod.Page) {
+		p.MustEval(`() => window.instrumented = true`)
+		loads <- p.MustInfo().URL
+	})
+
+	page.MustNavigate(srcFile("fixtures/click.html"))
+	s.Contains(<-loads, "click.html")
+	s.True(page.MustEval(`window.instrumented`).Bool())
+
+	page.MustReload()
+	s.Contains(<-loads, "click.html")
+	s.True(page.MustEval(`window.instrumented`).Bool())
+
+	stop()
+}
+
 func (s *S) TestPageWaitOpen() {
 	page := s.page.Timeout(3 * time.Second).MustNavigate(srcFile("fixtures/open-page.html"))
 	defer page.CancelTimeout()
@@ -319,6 +726,20 @@ func (s *S) TestPageWaitOpen() {
 	s.Equal("new page", newPage.MustEval("window.a").String())
 }
 
+func (s *S) TestPageWaitOpenPopup() {
+	page := s.page.Timeout(3 * time.Second).MustNavigate(srcFile("fixtures/click.html"))
+	defer page.CancelTimeout()
+
+	wait := page.MustWaitOpen()
+
+	page.MustEval(`window.open('about:blank', 'popup', 'width=300,height=300')`)
+
+	popup := wait()
+	defer popup.MustClose()
+
+	s.True(popup.MustEval(`window.opener !== null`).Bool())
+}
+
 func (s *S) TestPageWaitPauseOpen() {
 	page := s.page.Timeout(5 * time.Second).MustNavigate(srcFile("fixtures/open-page.html"))
 	defer page.CancelTimeout()
@@ -388,61 +809,266 @@ func (s *S) TestPageWaitNavigation() {
 	wait()
 }
 
-func (s *S) TestPageWaitRequestIdle() {
-	url, mux, close := utils.Serve("")
-	defer close()
-
-	sleep := 2 * time.Second
-	timeout, cancel := context.WithTimeout(context.Background(), sleep)
-	defer cancel()
+func (s *S) TestPageWaitNavigationLifecycle() {
+	url, mux, closeSvr := utils.Serve("")
+	defer closeSvr()
 
-	mux.HandleFunc("/r1", func(w http.ResponseWriter, r *http.Request) {})
-	mux.HandleFunc("/r2", func(w http.ResponseWriter, r *http.Request) {
-		<-timeout.Done()
-	})
-	mux.HandleFunc("/", httpHTML(`<html>
-		<button>click</button>
-		<script>
-			document.querySelector("button").onclick = () => {
-				fetch('/r2').then(r => r.text())
-				fetch('/r1')
-			}
-		</script>
-	</html>`))
+	release := make(chan struct{})
+	mux.HandleFunc("/img", func(w http.ResponseWriter, r *http.Request) { <-release })
+	mux.HandleFunc("/", httpHTML(`<html><img src="/img"></html>`))
 
-	page := s.page.MustNavigate(url)
+	p := s.browser.MustPage("")
+	defer p.MustClose()
 
-	wait := page.MustWaitRequestIdle("/r1")
-	start := time.Now()
-	page.MustElement("button").MustClick()
-	s.browser.Trace(true)
+	wait := p.WaitNavigation(proto.PageLifecycleEventNameDOMContentLoaded)
+	p.MustNavigate(url)
 	wait()
-	s.browser.Trace(defaults.Trace)
-	s.Greater(time.Since(start), sleep)
 
-	wait = page.MustWaitRequestIdle("/r2")
-	page.MustElement("button").MustClick()
-	start = time.Now()
-	wait()
-	s.Less(time.Since(start), sleep)
+	// the image is still loading
+	s.Equal("interactive", p.MustEval(`document.readyState`).String())
 
-	s.Panics(func() {
-		wait()
-	})
+	close(release)
+	p.MustWaitLoad()
+	s.Equal("complete", p.MustEval(`document.readyState`).String())
 }
 
-func (s *S) TestPageWaitIdle() {
-	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
-	p.MustElement("button").MustClick()
-	p.MustWaitIdle()
+func (s *S) TestPageHAR() {
+	url, mux, close := utils.Serve("")
+	defer close()
 
-	s.True(p.MustHas("[a=ok]"))
-}
+	mux.HandleFunc("/api/data", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		utils.E(w.Write([]byte(`{"ok":true}`)))
+	})
+	mux.HandleFunc("/api/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/api/data", http.StatusFound)
+	})
+	mux.HandleFunc("/api/broken", func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		utils.E(err)
+		utils.E(conn.Close())
+	})
+	mux.HandleFunc("/", httpHTML(`<html></html>`))
+
+	page := s.browser.MustPage("")
+	defer page.MustClose()
+
+	_, err := page.StopHAR()
+	s.ErrorIs(err, rod.ErrHARNotStarted)
+
+	page.MustStartHAR()
+	page.MustNavigate(url).MustWaitLoad()
+	page.MustEval(`() => fetch('/api/data?a=1', { method: 'POST', body: 'x=1' }).then(r => r.text())`)
+	page.MustEval(`() => fetch('/api/old').then(r => r.text())`)
+	page.MustEval(`() => fetch('/api/broken').catch(() => {})`)
+	page.MustWaitRequestIdle()()
+	har := page.MustStopHAR()
+
+	s.Equal("1.2", har.Log.Version)
+	s.Equal("rod", har.Log.Creator.Name)
+
+	entries := []*rod.HAREntry{}
+	for _, e := range har.Log.Entries {
+		if e.Request.URL != url+"/favicon.ico" {
+			entries = append(entries, e)
+		}
+	}
+	s.Len(entries, 5)
+
+	doc := entries[0]
+	s.Equal(url+"/", doc.Request.URL)
+	s.Equal(http.StatusOK, doc.Response.Status)
+	s.Equal("text/html", doc.Response.Content.MimeType)
+	s.Greater(doc.Time, 0.0)
+
+	post := entries[1]
+	s.Equal(http.MethodPost, post.Request.Method)
+	s.Equal([]*rod.HARNameValue{{Name: "a", Value: "1"}}, post.Request.QueryString)
+	s.Equal("x=1", post.Request.PostData.Text)
+	s.Equal(len(`{"ok":true}`), post.Response.Content.Size)
+
+	s.Equal(http.StatusFound, entries[2].Response.Status)
+	s.Equal(url+"/api/data", entries[2].Response.RedirectURL)
+	s.Equal(http.StatusOK, entries[3].Response.Status)
+
+	broken := entries[4]
+	s.Equal(0, broken.Response.Status)
+	s.NotEmpty(broken.Response.Comment)
+
+	// it should be valid json for the HAR analyzers
+	s.Contains(utils.MustToJSON(har), `"startedDateTime":`)
+
+	// nothing should be recorded after the stop
+	count := len(har.Log.Entries)
+	page.MustEval(`() => fetch('/api/data').then(r => r.text())`)
+	s.Len(har.Log.Entries, count)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.NetworkEnable{})
+		page.MustStartHAR()
+	})
+}
+
+func (s *S) TestPageWaitLifecycle() {
+	url, mux, closeSvr := utils.Serve("")
+	defer closeSvr()
+
+	release := make(chan struct{})
+	mux.HandleFunc("/img", func(w http.ResponseWriter, r *http.Request) { <-release })
+	mux.HandleFunc("/frame", httpHTML(`<html><body>frame</body></html>`))
+	mux.HandleFunc("/", httpHTML(`<html><iframe src="/frame"></iframe><img src="/img"></html>`))
+
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	wait := p.MustWaitDOMStable()
+	p.MustNavigate(url)
+	wait()
+	s.Equal("interactive", p.MustEval(`document.readyState`).String())
+
+	// the load of the iframe shouldn't end the wait
+	wait = p.MustWaitLifecycle(proto.PageLifecycleEventNameLoad)
+	p.MustNavigate(url)
+	go func() {
+		utils.Sleep(0.3)
+		close(release)
+	}()
+	wait()
+	s.Equal("complete", p.MustEval(`document.readyState`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageSetLifecycleEventsEnabled{})
+		p.MustWaitDOMStable()
+	})
+}
+
+func (s *S) TestPageWaitRequestIdle() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	sleep := 2 * time.Second
+	timeout, cancel := context.WithTimeout(context.Background(), sleep)
+	defer cancel()
+
+	mux.HandleFunc("/r1", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/r2", func(w http.ResponseWriter, r *http.Request) {
+		<-timeout.Done()
+	})
+	mux.HandleFunc("/", httpHTML(`<html>
+		<button>click</button>
+		<script>
+			document.querySelector("button").onclick = () => {
+				fetch('/r2').then(r => r.text())
+				fetch('/r1')
+			}
+		</script>
+	</html>`))
+
+	page := s.page.MustNavigate(url)
+
+	wait := page.MustWaitRequestIdle("/r1")
+	start := time.Now()
+	page.MustElement("button").MustClick()
+	s.browser.Trace(true)
+	wait()
+	s.browser.Trace(defaults.Trace)
+	s.Greater(time.Since(start), sleep)
+
+	wait = page.MustWaitRequestIdle("/r2")
+	page.MustElement("button").MustClick()
+	start = time.Now()
+	wait()
+	s.Less(time.Since(start), sleep)
+
+	s.Panics(func() {
+		wait()
+	})
+}
+
+func (s *S) TestPageWaitRequest() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	mux.HandleFunc("/api/other", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/api/save", func(w http.ResponseWriter, r *http.Request) {
+		utils.E(w.Write([]byte("saved")))
+	})
+	mux.HandleFunc("/api/broken", func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		utils.E(err)
+		utils.E(conn.Close())
+	})
+	mux.HandleFunc("/", httpHTML(`<html>
+		<button id="save" onclick="fetch('/api/other').then(() => fetch('/api/save'))">save</button>
+		<button id="broken" onclick="fetch('/api/broken')">broken</button>
+	</html>`))
+
+	page := s.browser.MustPage(url).MustWaitLoad()
+	defer page.MustClose()
+
+	wait := page.MustWaitRequest(`/api/save$`)
+	page.MustElement("#save").MustClick()
+	res, body := wait()
+	s.Equal(url+"/api/save", res.Response.URL)
+	s.EqualValues(http.StatusOK, res.Response.Status)
+	s.Equal("saved", string(body))
+
+	waitErr := page.WaitRequest(`/api/broken$`)
+	page.MustElement("#broken").MustClick()
+	_, _, err := waitErr()
+	s.ErrorIs(err, rod.ErrRequestFailed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = page.Context(ctx).WaitRequest(`/not-exists`)()
+	s.ErrorIs(err, context.Canceled)
+
+	s.Panics(func() {
+		wait := page.MustWaitRequest(`/api/save$`)
+		s.mc.stubErr(1, proto.NetworkGetResponseBody{})
+		page.MustElement("#save").MustClick()
+		wait()
+	})
+}
+
+func (s *S) TestPageWaitIdle() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustElement("button").MustClick()
+	p.MustWaitIdle()
+
+	s.True(p.MustHas("[a=ok]"))
+}
 
 func (s *S) TestPageWaitEvent() {
-	wait := s.page.WaitEvent(&proto.PageFrameNavigated{})
+	e := &proto.PageFrameNavigated{}
+	wait := s.page.WaitEvent(e)
 	s.page.MustNavigate(srcFile("fixtures/click.html"))
 	wait()
+	s.Regexp(`/fixtures/click.html\z`, e.Frame.URL)
+
+	// timeout before the event arrives
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e = &proto.PageFrameNavigated{}
+	s.page.Context(ctx).WaitEvent(e)()
+	s.Nil(e.Frame)
+}
+
+func (s *S) TestPageEventChannel() {
+	events, stop := s.page.EventChannel(&proto.PageFrameNavigated{}, 1)
+	s.page.MustNavigate(srcFile("fixtures/click.html"))
+	e := (<-events).(*proto.PageFrameNavigated)
+	s.Regexp(`/fixtures/click.html\z`, e.Frame.URL)
+	stop()
+
+	for range events {
+	}
+
+	events, stop = s.browser.EventChannel(&proto.TargetTargetCreated{}, 0)
+	page := s.browser.MustPage("")
+	defer page.MustClose()
+	s.Equal(page.TargetID, (<-events).(*proto.TargetTargetCreated).TargetInfo.TargetID)
+	stop()
 }
 
 func (s *S) TestAlert() {
@@ -484,6 +1110,19 @@ func (s *S) TestMouse() {
 	})
 }
 
+func (s *S) TestMouseInvalidButton() {
+	mouse := s.page.Mouse
+
+	err := mouse.Down("Left", 1)
+	s.True(errors.Is(err, rod.ErrInvalidMouseButton))
+	s.Contains(err.Error(), `"Left", it should be one of`)
+
+	err = mouse.Up("typo", 1)
+	s.True(errors.Is(err, rod.ErrInvalidMouseButton))
+
+	s.Error(mouse.Click("Left"))
+}
+
 func (s *S) TestMouseClick() {
 	s.browser.Slowmotion(1)
 	defer func() { s.browser.Slowmotion(0) }()
@@ -522,6 +1161,239 @@ func (s *S) TestMouseDrag() {
 	s.Equal([]string{"move 3 3", "down 3 3", "move 22 28", "move 41 54", "move 60 80", "up 60 80"}, logs)
 }
 
+func (s *S) TestMouseDragPath() {
+	page := s.page.MustNavigate(srcFile("fixtures/drag.html")).MustWaitLoad()
+	mouse := page.Mouse
+
+	wait := make(chan struct{})
+	logs := []string{}
+	go page.EachEvent(func(e *proto.RuntimeConsoleAPICalled) bool {
+		log := page.MustObjectsToJSON(e.Args).Join(" ")
+		logs = append(logs, log)
+		if strings.HasPrefix(log, `up`) {
+			close(wait)
+			return true
+		}
+		return false
+	})()
+
+	mouse.MustMove(3, 3)
+	mouse.MustDragPath([]proto.Point{{X: 10, Y: 40}, {X: 60, Y: 20}}, time.Millisecond)
+
+	<-wait
+
+	s.Equal([]string{"move 3 3", "down 3 3", "move 10 40", "move 60 20", "up 60 20"}, logs)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+		mouse.MustDragPath(nil, 0)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+		mouse.MustDragPath([]proto.Point{{X: 1, Y: 1}}, 0)
+	})
+}
+
+func (s *S) TestMouseDragPathCancel() {
+	ctx, cancel := context.WithCancel(context.Background())
+	page := s.browser.Context(ctx).MustPage(srcFile("fixtures/drag.html")).MustWaitLoad()
+	defer page.Context(context.Background()).MustClose()
+
+	go func() {
+		utils.Sleep(0.3)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := page.Mouse.DragPath([]proto.Point{{X: 10, Y: 40}, {X: 60, Y: 20}}, time.Hour)
+	s.ErrorIs(err, context.Canceled)
+	s.Less(time.Since(start), 10*time.Second)
+}
+
+func (s *S) TestMouseDragFromTo() {
+	page := s.page.MustNavigate(srcFile("fixtures/drag.html")).MustWaitLoad()
+	mouse := page.Mouse
+
+	wait := make(chan struct{})
+	logs := []string{}
+	go page.EachEvent(func(e *proto.RuntimeConsoleAPICalled) bool {
+		log := page.MustObjectsToJSON(e.Args).Join(" ")
+		logs = append(logs, log)
+		if strings.HasPrefix(log, `up`) {
+			close(wait)
+			return true
+		}
+		return false
+	})()
+
+	mouse.MustDrag(proto.Point{X: 3, Y: 3}, proto.Point{X: 13, Y: 23}, proto.InputMouseButtonLeft, 2)
+
+	<-wait
+
+	s.Equal([]string{"move 3 3", "down 3 3", "move 8 13", "move 13 23", "up 13 23"}, logs)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+		mouse.MustDrag(proto.Point{X: 1, Y: 1}, proto.Point{X: 2, Y: 2}, proto.InputMouseButtonLeft, 1)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+		mouse.MustDrag(proto.Point{X: 2, Y: 2}, proto.Point{X: 3, Y: 3}, proto.InputMouseButtonLeft, 1)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(3, proto.InputDispatchMouseEvent{})
+		mouse.MustDrag(proto.Point{X: 3, Y: 3}, proto.Point{X: 4, Y: 4}, proto.InputMouseButtonLeft, 1)
+	})
+}
+
+func (s *S) TestMouseReplayCancel() {
+	ctx, cancel := context.WithCancel(context.Background())
+	page := s.browser.Context(ctx).MustPage(srcFile("fixtures/click.html")).MustWaitLoad()
+	defer page.Context(context.Background()).MustClose()
+
+	go func() {
+		utils.Sleep(0.3)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := page.Mouse.Replay([]rod.MouseEvent{
+		{Type: proto.InputDispatchMouseEventTypeMouseMoved, X: 10, Y: 10},
+		{Type: proto.InputDispatchMouseEventTypeMouseMoved, X: 20, Y: 20, Time: time.Hour},
+	})
+	s.ErrorIs(err, context.Canceled)
+	s.Less(time.Since(start), 10*time.Second)
+}
+
+func (s *S) TestMouseRecordAndReplay() {
+	page := s.page.MustNavigate(srcFile("fixtures/click.html")).MustWaitLoad()
+	mouse := page.Mouse
+
+	brief := func(list []rod.MouseEvent) []string {
+		out := []string{}
+		for _, e := range list {
+			out = append(out, fmt.Sprintf("%s %s %d %d", e.Type, e.Button, int(e.X), int(e.Y)))
+		}
+		return out
+	}
+
+	stop := mouse.MustRecord()
+	mouse.MustMove(10, 20)
+	mouse.MustDown("left")
+	mouse.MustMove(30, 40)
+	mouse.MustUp("left")
+	events := stop()
+
+	expected := []string{
+		"mouseMoved none 10 20",
+		"mousePressed left 10 20",
+		"mouseMoved none 30 40",
+		"mouseReleased left 30 40",
+	}
+	s.Equal(expected, brief(events))
+	s.Len(stop(), 0)
+
+	mouse.MustMove(0, 0)
+	stop = mouse.MustRecord()
+	mouse.MustReplay(events)
+	s.Equal(expected, brief(stop()))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		mouse.MustRecord()
+	})
+	s.Panics(func() {
+		stop := mouse.MustRecord()
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		stop()
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+		mouse.MustReplay(events)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+		mouse.MustReplay(events[1:2])
+	})
+}
+
+func (s *S) TestMouseMoveFractional() {
+	page := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	page.MustEval(`() => {
+		window.points = []
+		window.onpointermove = e => points.push([e.clientX, e.clientY])
+	}`)
+
+	page.Mouse.MustMove(10.5, 20.25)
+
+	s.Equal("[[10.5,20.25]]", page.MustEval(`points`).Raw)
+}
+
+func (s *S) TestPageShowMouseTrail() {
+	p := s.browser.MustPage(srcFile("fixtures/click.html")).MustWaitLoad()
+	defer p.MustClose()
+
+	p.Mouse.MustMove(10, 10)
+	p.ShowMouseTrail(true)
+	p.Mouse.MustMove(20, 30)
+	p.Mouse.MustMove(40, 50)
+
+	s.Equal("10,10 20,30 40,50", p.MustEval(`document.querySelector('body > svg polyline').getAttribute('points')`).String())
+	s.Equal("none", p.MustEval(`getComputedStyle(document.querySelector('body > svg')).pointerEvents`).String())
+
+	p.ShowMouseTrail(false)
+	s.False(p.MustHas("body > svg"))
+
+	p.Mouse.MustMove(60, 70)
+	s.False(p.MustHas("body > svg"))
+}
+
+func (s *S) TestPageStartInspect() {
+	p := s.browser.MustPage(srcFile("fixtures/click.html")).MustWaitLoad()
+	defer p.MustClose()
+
+	quad := p.MustElement("button").MustShape()[0]
+
+	picked, stop, err := p.StartInspect()
+	utils.E(err)
+
+	p.Mouse.MustMove(quad.CenterX(), quad.CenterY())
+	p.Mouse.MustClick(proto.InputMouseButtonLeft)
+
+	el := <-picked
+	s.Equal("click me", el.MustText())
+
+	// the click is captured by the inspect mode, it won't reach the page
+	s.False(p.MustHas("[a=ok]"))
+
+	stop()
+	_, ok := <-picked
+	s.False(ok)
+
+	s.mc.stubErr(1, proto.OverlaySetInspectMode{})
+	_, _, err = p.StartInspect()
+	s.Error(err)
+}
+
+func (s *S) TestMouseMoveDefaultSteps() {
+	page := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	mouse := page.Mouse
+	mouse.MustMove(0, 0)
+
+	defer func(steps int) { rod.DefaultMouseMoveSteps = steps }(rod.DefaultMouseMoveSteps)
+	rod.DefaultMouseMoveSteps = 3
+
+	stop := mouse.MustRecord()
+	mouse.MustMove(30, 60)
+	events := stop()
+
+	s.Len(events, 3)
+	s.Equal(10.0, events[0].X)
+	s.Equal(20.0, events[0].Y)
+	s.Equal(30.0, events[2].X)
+	s.Equal(60.0, events[2].Y)
+}
+
 func (s *S) TestNativeDrag() {
 	// devtools doesn't support to use mouse event to simulate it for now
 	s.T().SkipNow()
@@ -587,6 +1459,27 @@ func (s *S) TestTouch() {
 	})
 }
 
+func (s *S) TestTouchMultiTap() {
+	page := s.browser.MustPage("")
+	defer page.MustClose()
+
+	page.MustEmulate(devices.IPad).
+		MustNavigate(srcFile("fixtures/touch.html")).
+		MustWaitLoad()
+
+	page.MustEval(`document.body.addEventListener('touchstart', (e) => {
+		document.body.setAttribute('touches', e.touches.length)
+	})`)
+
+	page.Touch.MustMultiTap(proto.Point{X: 10, Y: 20}, proto.Point{X: 100, Y: 120})
+	s.True(page.MustHas("body[touches='2']"))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputDispatchTouchEvent{})
+		page.Touch.MustMultiTap(proto.Point{X: 1, Y: 2})
+	})
+}
+
 func (s *S) TestPageScreenshot() {
 	f := filepath.Join("tmp", "screenshots", utils.RandString(8)+".png")
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
@@ -612,6 +1505,57 @@ func (s *S) TestPageScreenshot() {
 	})
 }
 
+func (s *S) TestPageLayoutMetrics() {
+	p := s.page.MustNavigate(srcFile("fixtures/scroll.html")).MustWaitLoad()
+	p.MustEval(`scrollTo(100, 200)`)
+
+	m := p.MustLayoutMetrics()
+	s.Greater(m.ContentSize.Width, 2000.0)
+	s.Greater(m.ContentSize.Height, 1500.0)
+	s.EqualValues(100, m.LayoutViewport.PageX)
+	s.EqualValues(200, m.LayoutViewport.PageY)
+	s.EqualValues(200, m.VisualViewport.PageY)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageGetLayoutMetrics{})
+		p.MustLayoutMetrics()
+	})
+}
+
+func (s *S) TestPageAXTree() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustElement("button")
+
+	root := p.MustAXTree()
+	s.Equal("RootWebArea", root.Role.Value.String())
+
+	var find func(n *rod.AXTreeNode) *rod.AXTreeNode
+	find = func(n *rod.AXTreeNode) *rod.AXTreeNode {
+		if n.Role != nil && n.Role.Value.String() == "button" {
+			return n
+		}
+		for _, c := range n.Children {
+			if found := find(c); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	s.Equal("click me", find(root).Name.Value.String())
+
+	s.mc.stub(1, proto.AccessibilityGetFullAXTree{}, func(send func() ([]byte, error)) ([]byte, error) {
+		return utils.MustToJSONBytes(proto.AccessibilityGetFullAXTreeResult{
+			Nodes: []*proto.AccessibilityAXNode{},
+		}), nil
+	})
+	s.Error(lastE(p.AXTree()))
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.AccessibilityGetFullAXTree{})
+		p.MustAXTree()
+	})
+}
+
 func (s *S) TestScreenshotFullPage() {
 	p := s.page.MustNavigate(srcFile("fixtures/scroll.html"))
 	p.MustElement("button")
@@ -657,6 +1601,74 @@ func (s *S) TestScreenshotFullPageInit() {
 	p.MustScreenshotFullPage()
 }
 
+func (s *S) TestKeyboardShortcut() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`() => {
+		window.keys = []
+		document.addEventListener('keydown', e => {
+			if (['Control', 'Shift', 'Alt', 'Meta'].includes(e.key)) return
+			keys.push([e.ctrlKey, e.shiftKey, e.altKey, e.metaKey, e.key, e.code].join(' '))
+		})
+	}`)
+
+	p.Keyboard.MustShortcut("Ctrl+Shift+K")
+	p.Keyboard.MustShortcut("alt+f4")
+	p.Keyboard.MustShortcut("Cmd+Enter")
+	p.Keyboard.MustShortcut("Ctrl+a")
+	p.Keyboard.MustShortcut("Escape")
+	p.Keyboard.MustShortcut("Ctrl+Space")
+	p.Keyboard.MustShortcut("Ctrl++")
+
+	s.Equal([]interface{}{
+		"true true false false K KeyK",
+		"false false true false F4 F4",
+		"false false false true Enter Enter",
+		"true false false false a KeyA",
+		"false false false false Escape Escape",
+		"true false false false   Space",
+		"true true false false + Equal",
+	}, p.MustEval(`() => keys`).Value())
+
+	s.ErrorIs(p.Keyboard.Shortcut("Hyper+K"), rod.ErrInvalidShortcut)
+	s.ErrorIs(p.Keyboard.Shortcut("Ctrl+NotAKey"), rod.ErrInvalidShortcut)
+	s.ErrorIs(p.Keyboard.Shortcut(""), rod.ErrInvalidShortcut)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+		p.Keyboard.MustShortcut("Ctrl+K")
+	})
+}
+
+func (s *S) TestKeyboardSetInputEvent() {
+	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
+
+	el := p.MustElement("input")
+	el.MustEval(`() => {
+		this.changes = 0
+		this.onchange = () => this.changes++
+	}`)
+	el.MustFocus()
+
+	p.Keyboard.MustInsertText("a")
+	s.Equal(0, el.MustEval(`this.changes`).Int())
+
+	p.Keyboard.SetInputEvent(true)
+	defer p.Keyboard.SetInputEvent(false)
+
+	p.Keyboard.MustInsertText("b")
+	s.Equal(1, el.MustEval(`this.changes`).Int())
+	s.Equal("ab", el.MustText())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.InputInsertText{})
+		p.Keyboard.MustInsertText("c")
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		p.Keyboard.MustInsertText("c")
+	})
+}
+
 func (s *S) TestPageInput() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 
@@ -695,6 +1707,28 @@ func (s *S) TestPageScroll() {
 	s.Less(int64(300), offset.Get("y").Int())
 }
 
+func (s *S) TestMouseWheel() {
+	p := s.page.MustNavigate(srcFile("fixtures/scroll.html")).MustWaitLoad()
+	p.MustEval(`() => {
+		window.wheels = []
+		window.addEventListener('wheel', e => wheels.push([e.deltaY, e.deltaMode, e.shiftKey]))
+	}`)
+
+	p.Mouse.MustMove(10, 10)
+	p.Mouse.MustWheel(0, 30, rod.WheelModePixel)
+	p.Mouse.MustWheel(0, 3, rod.WheelModeLine)
+	p.Keyboard.MustDown(input.Shift)
+	p.Mouse.MustWheel(0, 1, rod.WheelModePage)
+	p.Keyboard.MustUp(input.Shift)
+
+	s.Equal(`[[30,0,false],[3,1,false],[1,2,true]]`, p.MustEval(`() => JSON.stringify(wheels)`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		p.Mouse.MustWheel(0, 1, rod.WheelModeLine)
+	})
+}
+
 func (s *S) TestPageConsoleLog() {
 	p := s.page.MustNavigate("")
 	e := &proto.RuntimeConsoleAPICalled{}
@@ -734,6 +1768,99 @@ func (s *S) TestPagePDF() {
 	})
 }
 
+func (s *S) TestPagePDFHeaderFooter() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+
+	pdf := func(req *proto.PagePrintToPDF) []byte {
+		r, err := p.PDF(req)
+		utils.E(err)
+		bin, err := ioutil.ReadAll(r)
+		utils.E(err)
+		return bin
+	}
+
+	plain := pdf(&proto.PagePrintToPDF{})
+	withFooter := pdf(&proto.PagePrintToPDF{
+		DisplayHeaderFooter: true,
+		HeaderTemplate:      `<span></span>`,
+		FooterTemplate: `<div style="font-size: 10px">
+			<span class="pageNumber"></span> of <span class="totalPages"></span></div>`,
+	})
+
+	s.Equal("%PDF", string(withFooter[:4]))
+	s.Greater(len(withFooter), len(plain))
+}
+
+func (s *S) TestPageCaptureSnapshot() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	s.Contains(p.MustCaptureSnapshot(""), "Content-Type: multipart/related")
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageCaptureSnapshot{})
+		p.MustCaptureSnapshot()
+	})
+}
+
+func (s *S) TestPageStealthInit() {
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	p.MustStealthInit().MustNavigate(srcFile("fixtures/click.html")).MustWaitLoad()
+
+	s.False(p.MustEval(`navigator.webdriver`).Bool())
+	s.NotZero(p.MustEval(`navigator.languages.length`).Int())
+	s.NotZero(p.MustEval(`navigator.plugins.length`).Int())
+	s.True(p.MustEval(`!!window.chrome.runtime`).Bool())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
+		p.MustStealthInit()
+	})
+}
+
+func (s *S) TestPageExposeValues() {
+	p := s.browser.MustPage("")
+	defer p.MustClose()
+
+	ev := p.MustExposeValues("flags", map[string]interface{}{"dark": true, "lang": "en"})
+	p.MustNavigate(srcFile("fixtures/click.html"))
+	s.True(p.MustEval(`flags('dark')`).Bool())
+	s.Equal("en", p.MustEval(`flags('lang')`).String())
+	s.True(p.MustEval(`flags('none') === undefined`).Bool())
+
+	ev.MustSet("lang", "fr")
+	s.Equal("fr", p.MustEval(`flags('lang')`).String())
+	p.MustReload().MustWaitLoad()
+	s.Equal("fr", p.MustEval(`flags('lang')`).String())
+
+	// the missing key will be filled by the binding
+	p.MustEval(`delete flags.cache.dark`)
+	p.Timeout(5 * time.Second).MustWait(`() => flags('dark') === true`)
+
+	ev.MustRemove()
+	p.MustNavigate(srcFile("fixtures/click.html"))
+	s.Equal("undefined", p.MustEval(`typeof flags`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeAddBinding{})
+		p.MustExposeValues("flags", nil)
+	})
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
+		p.MustExposeValues("flags", nil)
+	})
+	s.Panics(func() {
+		ev := p.MustExposeValues("flags", nil)
+		s.mc.stubErr(1, proto.PageRemoveScriptToEvaluateOnNewDocument{})
+		ev.MustSet("a", 1)
+	})
+	s.Panics(func() {
+		ev := p.MustExposeValues("flags", nil)
+		s.mc.stubErr(1, proto.RuntimeRemoveBinding{})
+		ev.MustRemove()
+	})
+}
+
 func (s *S) TestPageExpose() {
 	cb, stop := s.page.MustExpose("exposedFunc")
 	page := s.page.MustNavigate(srcFile("fixtures/click.html"))
@@ -803,6 +1930,55 @@ func (s *S) TestPageNavigateErr() {
 	})
 }
 
+func (s *S) TestPageNavigateWithOptions() {
+	url, mux, close := utils.Serve("")
+	defer close()
+
+	referrer := make(chan string, 1)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case referrer <- r.Header.Get("Referer"):
+		default:
+		}
+		httpHTML(`<html><body>ok</body></html>`)(w, r)
+	})
+
+	page := s.browser.MustPage("")
+	defer page.MustClose()
+
+	utils.E(page.NavigateWithOptions(url, &rod.NavigateOptions{
+		Referrer:  "http://test.com/",
+		WaitUntil: proto.PageLifecycleEventNameLoad,
+		Timeout:   time.Minute,
+	}))
+	s.Equal("http://test.com/", <-referrer)
+	s.Equal("complete", page.MustEval(`document.readyState`).String())
+
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	s.Error(page.NavigateWithOptions(url+"/slow", &rod.NavigateOptions{
+		WaitUntil: proto.PageLifecycleEventNameLoad,
+		Timeout:   100 * time.Millisecond,
+	}))
+
+	// the events of the iframe shouldn't be taken as the main document's
+	mux.HandleFunc("/frame", httpHTML(`<html><body>frame</body></html>`))
+	mux.HandleFunc("/slow.js", func(w http.ResponseWriter, r *http.Request) {
+		utils.Sleep(0.5)
+		w.Header().Set("Content-Type", "application/javascript")
+	})
+	mux.HandleFunc("/main", httpHTML(`<html><body><iframe src="/frame"></iframe><script src="/slow.js"></script></body></html>`))
+	utils.E(page.NavigateWithOptions(url+"/main", &rod.NavigateOptions{
+		WaitUntil: proto.PageLifecycleEventNameDOMContentLoaded,
+		Timeout:   time.Minute,
+	}))
+	s.NotEqual("loading", page.MustEval(`document.readyState`).String())
+
+	s.mc.stubErr(1, proto.PageNavigate{})
+	s.Error(page.NavigateWithOptions(url, &rod.NavigateOptions{WaitUntil: proto.PageLifecycleEventNameLoad}))
+}
+
 func (s *S) TestPageWaitLoadErr() {
 	s.Panics(func() {
 		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
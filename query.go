@@ -182,6 +182,17 @@ func (p *Page) ElementByJS(opts *EvalOptions) (*Element, error) {
 	return p.ElementFromObject(res.ObjectID), nil
 }
 
+// FrameByName retries until an iframe or frame whose name or id equals the name, then returns the page
+// that represents it. It's handy for the multi-frame apps that reference the frames by their names.
+func (p *Page) FrameByName(name string) (*Page, error) {
+	el, err := p.ElementByJS(NewEvalOptions(`name => Array.from(document.querySelectorAll('iframe, frame'))
+		.find(f => f.name === name || f.id === name) || null`, JSArgs{name}))
+	if err != nil {
+		return nil, err
+	}
+	return el.Frame()
+}
+
 // Elements returns all elements that match the css selector
 func (p *Page) Elements(selector string) (Elements, error) {
 	return p.ElementsByJS(jsHelper(js.Elements, JSArgs{selector}))
@@ -268,7 +279,7 @@ func (p *Page) Search(from, to int, queries ...string) (Elements, error) {
 		}.Call(p)
 		if err != nil {
 			// when the page is still loading the search result is not ready
-			if isNilContextErr(err) {
+			if isSearchNotReadyErr(err) {
 				return false, nil
 			}
 			return true, err
@@ -408,6 +419,28 @@ func (el *Element) Element(selectors ...string) (*Element, error) {
 	return el.ElementByJS(jsHelper(js.Element, JSArgsFromString(selectors)))
 }
 
+// QueryShadow returns the element that matches the chain of css selectors,
+// each selector is queried inside the shadow root of the element matched by the previous one.
+func (el *Element) QueryShadow(selectors ...string) (*Element, error) {
+	cur := el
+	for i, selector := range selectors {
+		if i > 0 {
+			root, err := cur.ShadowRoot()
+			if err != nil {
+				return nil, err
+			}
+			cur = root
+		}
+
+		next, err := cur.Element(selector)
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
 // ElementX returns the first child that matches the XPath selector
 func (el *Element) ElementX(xPaths ...string) (*Element, error) {
 	return el.ElementByJS(jsHelper(js.ElementX, JSArgsFromString(xPaths)))
@@ -428,6 +461,12 @@ func (el *Element) Parents(selector string) (Elements, error) {
 	return el.ElementsByJS(jsHelper(js.Parents, JSArgs{selector}))
 }
 
+// ScrollParent returns the first ancestor that its computed overflow style is "auto" or "scroll".
+// If there's no such ancestor, the document.scrollingElement will be returned.
+func (el *Element) ScrollParent() (*Element, error) {
+	return el.ElementByJS(jsHelper(js.ScrollParent, nil))
+}
+
 // Next returns the next sibling element in the DOM tree
 func (el *Element) Next() (*Element, error) {
 	return el.ElementByJS(NewEvalOptions(`this.nextElementSibling`, nil))
@@ -453,7 +492,8 @@ func (el *Element) ElementsX(xpath string) (Elements, error) {
 	return el.ElementsByJS(jsHelper(js.ElementsX, JSArgs{xpath}))
 }
 
-// ElementsByJS returns the elements from the return value of the js
+// ElementsByJS returns the elements from the return value of the js, the "this" of the js is the element.
+// The js should return an array of nodes, such as `() => Array.from(this.children)`.
 func (el *Element) ElementsByJS(opts *EvalOptions) (Elements, error) {
 	return el.page.Context(el.ctx).Sleeper(nil).ElementsByJS(opts.This(el.ObjectID))
 }
@@ -1,7 +1,9 @@
 package rod_test
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/cdp"
@@ -76,6 +78,15 @@ func (s *S) TestSearch() {
 		p.MustSearch("click me")
 	}
 
+	// fatal errors should fail immediately rather than retry until the timeout
+	{
+		s.mc.stub(1, proto.DOMGetSearchResults{}, func(func() ([]byte, error)) ([]byte, error) {
+			return nil, &cdp.Error{Code: -32000, Message: "Argument should belong to the same JavaScript world as target object"}
+		})
+		_, err := p.Timeout(time.Minute).Search(0, 1, "click me")
+		s.Error(err)
+	}
+
 	// when node id is zero
 	{
 		s.mc.stub(1, proto.DOMGetSearchResults{}, func(func() ([]byte, error)) ([]byte, error) {
@@ -116,6 +127,19 @@ func (s *S) TestSearchIframesAfterReload() {
 	s.True(el.MustClick().MustMatches("[a=ok]"))
 }
 
+func (s *S) TestPageElementRetry() {
+	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
+	p.MustEval(`() => setTimeout(() => document.body.insertAdjacentHTML('beforeend', '<p id="late">ok</p>'), 300)`)
+
+	_, err := p.Sleeper(nil).Element("#late")
+	s.ErrorIs(err, rod.ErrElementNotFound)
+
+	s.Equal("ok", p.Timeout(10*time.Second).MustElement("#late").MustText())
+
+	_, err = p.Timeout(100 * time.Millisecond).Element("#not-exists")
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
 func (s *S) TestPageElementWithSelectors() {
 	s.page.MustNavigate(srcFile("fixtures/selector.html"))
 	el := s.page.MustElement("p", "button")
@@ -202,6 +226,17 @@ func (s *S) TestElementParents() {
 	s.Len(p.MustElement("option").MustParents("form"), 1)
 }
 
+func (s *S) TestElementScrollParent() {
+	p := s.page.MustNavigate(srcFile("fixtures/scroll-parent.html"))
+	s.Equal("list", p.MustElement("#item").MustScrollParent().MustEval(`this.id`).String())
+	s.Equal("HTML", p.MustElement("#outside").MustScrollParent().MustEval(`this.tagName`).String())
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		p.MustElement("#item").MustScrollParent()
+	})
+}
+
 func (s *S) TestElementSiblings() {
 	p := s.page.MustNavigate(srcFile("fixtures/input.html"))
 	el := p.MustElement("hr")
@@ -234,6 +269,21 @@ func (s *S) TestElementTracing() {
 	s.Equal(`rod.element("code")`, p.MustElement("code").MustText())
 }
 
+func (s *S) TestPageFrameByName() {
+	p := s.page.MustNavigate(srcFile("fixtures/named-iframes.html"))
+
+	s.True(p.MustFrameByName("nav").MustHas("button"))
+	s.True(p.MustFrameByName("main").MustHas("textarea"))
+
+	_, err := p.Sleeper(nil).FrameByName("not-exists")
+	s.ErrorIs(err, rod.ErrElementNotFound)
+
+	s.Panics(func() {
+		s.mc.stubErr(1, proto.DOMDescribeNode{})
+		p.MustFrameByName("nav")
+	})
+}
+
 func (s *S) TestPageElementByJS_Err() {
 	p := s.page.MustNavigate(srcFile("fixtures/click.html"))
 	_, err := p.ElementByJS(rod.NewEvalOptions(`1`, nil))
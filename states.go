@@ -3,6 +3,7 @@ package rod
 import (
 	"encoding/json"
 
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 )
@@ -58,12 +59,18 @@ func (b *Browser) LoadState(sessionID proto.TargetSessionID, method proto.Payloa
 
 // EnableDomain and returns a recover function to restore previous state
 func (b *Browser) EnableDomain(sessionID proto.TargetSessionID, method proto.Payload) (recover func()) {
+	recover, _ = b.enableDomain(sessionID, method)
+	return
+}
+
+// same as EnableDomain, but returns the error of the enable call
+func (b *Browser) enableDomain(sessionID proto.TargetSessionID, method proto.Payload) (recover func(), err error) {
 	_, enabled := b.states.Load(b.key(sessionID, method.MethodName()))
 
 	if !enabled {
-		payload, err := proto.Normalize(method)
-		utils.E(err)
-		_, _ = b.Call(b.ctx, string(sessionID), method.MethodName(), payload)
+		payload, e := proto.Normalize(method)
+		utils.E(e)
+		_, err = b.Call(b.ctx, string(sessionID), method.MethodName(), payload)
 	}
 
 	return func() {
@@ -76,7 +83,7 @@ func (b *Browser) EnableDomain(sessionID proto.TargetSessionID, method proto.Pay
 			domain, _ := proto.ParseMethodName(method.MethodName())
 			_, _ = b.Call(b.ctx, string(sessionID), domain+".disable", nil)
 		}
-	}
+	}, err
 }
 
 // DisableDomain and returns a recover function to restore previous state
@@ -101,6 +108,23 @@ func (b *Browser) DisableDomain(sessionID proto.TargetSessionID, method proto.Pa
 	}
 }
 
+// the key to mark a session as crashed, it's not related to the browser context
+type crashKey proto.TargetSessionID
+
+func (b *Browser) trackCrash(msg *cdp.Event) {
+	switch msg.Method {
+	case (proto.InspectorTargetCrashed{}).MethodName():
+		b.states.Store(crashKey(msg.SessionID), true)
+	case (proto.InspectorTargetReloadedAfterCrash{}).MethodName():
+		b.states.Delete(crashKey(msg.SessionID))
+	}
+}
+
+func (b *Browser) crashed(sessionID proto.TargetSessionID) bool {
+	_, has := b.states.Load(crashKey(sessionID))
+	return has
+}
+
 func (b *Browser) storePage(page *Page) {
 	b.states.Store(page.TargetID, page)
 }
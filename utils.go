@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod/lib/assets/js"
@@ -70,6 +71,19 @@ type EvalOptions struct {
 
 	// Whether execution should be treated as initiated by user in the UI.
 	UserGesture bool
+
+	// Whether the preview should be generated for the result. It only works when ByValue is disabled,
+	// the preview can be found in the RuntimeRemoteObject.Preview.
+	GeneratePreview bool
+
+	// If enabled the JS will run in an isolated world of the frame, it shares the DOM with the page,
+	// but not the js globals, so the page can't break the JS by overriding builtins like Array.from.
+	// It only works when ThisID is empty, remote objects from the page's world can't be used as JSArgs.
+	IsolatedWorld bool
+
+	// If enabled and the context is done while the JS still blocks the main thread, such as an endless loop,
+	// the JS will be terminated. The page's own scripts running at that moment will be terminated too.
+	TerminateOnTimeout bool
 }
 
 // This set the ThisID
@@ -90,9 +104,27 @@ func (e *EvalOptions) ByUser() *EvalOptions {
 	return e
 }
 
+// WithPreview enables GeneratePreview.
+func (e *EvalOptions) WithPreview() *EvalOptions {
+	e.GeneratePreview = true
+	return e
+}
+
+// InIsolatedWorld enables IsolatedWorld.
+func (e *EvalOptions) InIsolatedWorld() *EvalOptions {
+	e.IsolatedWorld = true
+	return e
+}
+
+// WithTerminate enables TerminateOnTimeout.
+func (e *EvalOptions) WithTerminate() *EvalOptions {
+	e.TerminateOnTimeout = true
+	return e
+}
+
 // NewEvalOptions instance. ByValue will be set to true.
 func NewEvalOptions(js string, args JSArgs) *EvalOptions {
-	return &EvalOptions{true, "", js, args, false}
+	return &EvalOptions{true, "", js, args, false, false, false, false}
 }
 
 const jsHelperID = proto.RuntimeRemoteObjectID("rodJSHelper")
@@ -184,12 +216,33 @@ func Try(fn func()) (err error) {
 	return err
 }
 
+// The cdp errors that mean the js context is not ready or has been replaced, retrying them is safe.
+var nilContextErrs = []string{
+	"Cannot find context with specified id",
+	"Cannot find default execution context",
+	"Execution context was destroyed",
+	"Could not find object with given id",
+	"Inspected target navigated or closed",
+}
+
 func isNilContextErr(err error) bool {
-	if err == nil {
+	cdpErr, ok := err.(*cdp.Error)
+	if !ok || cdpErr.Code != -32000 {
 		return false
 	}
+	for _, msg := range nilContextErrs {
+		if strings.HasPrefix(cdpErr.Message, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// The DOM search returns -32000 while the document is still loading, only the js world mismatch is fatal
+func isSearchNotReadyErr(err error) bool {
 	cdpErr, ok := err.(*cdp.Error)
-	return ok && cdpErr.Code == -32000 && cdpErr.Message != "Argument should belong to the same JavaScript world as target object"
+	return ok && cdpErr.Code == -32000 &&
+		cdpErr.Message != "Argument should belong to the same JavaScript world as target object"
 }
 
 func genRegFilter(includes, excludes []string) func(string) bool {
@@ -224,6 +277,7 @@ type saveFileType int
 const (
 	saveFileTypeScreenshot saveFileType = iota
 	saveFileTypePDF
+	saveFileTypeSnapshot
 )
 
 func saveFile(fileType saveFileType, bin []byte, toFile []string) error {
@@ -237,6 +291,8 @@ func saveFile(fileType saveFileType, bin []byte, toFile []string) error {
 			toFile = []string{"tmp", "screenshots", stamp + ".png"}
 		case saveFileTypePDF:
 			toFile = []string{"tmp", "pdf", stamp + ".pdf"}
+		case saveFileTypeSnapshot:
+			toFile = []string{"tmp", "snapshots", stamp + ".mhtml"}
 		}
 	}
 	return utils.OutputFile(filepath.Join(toFile...), bin)
@@ -258,13 +314,15 @@ func mustToJSONForDev(value interface{}) string {
 }
 
 // detect if a js string is a function definition
-var regFn = regexp.MustCompile(`\A\s*function\s*\(`)
+var regFn = regexp.MustCompile(`\A\s*(async\s+)?function\s*\(`)
 
 // detect if a js string is a function definition
 // Samples:
 //
 // function () {}
+// async function () {}
 // a => {}
+// async () => {}
 // (a, b, c) =>
 // ({a: b}, ...list) => {}
 func detectJSFunction(js string) bool {